@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetClusterIngressCondition updates status to reflect the provided
+// condition, setting LastTransitionTime only if the condition's status
+// actually changed. It is a no-op if status already has an equivalent
+// condition.
+func SetClusterIngressCondition(status *ClusterIngressStatus, condition ClusterIngressCondition) {
+	existing := FindClusterIngressCondition(status, condition.Type)
+	if existing == nil {
+		if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		status.Conditions = append(status.Conditions, condition)
+		return
+	}
+
+	if existing.Status != condition.Status {
+		existing.Status = condition.Status
+		existing.LastTransitionTime = metav1.Now()
+	}
+	existing.Reason = condition.Reason
+	existing.Message = condition.Message
+}
+
+// FindClusterIngressCondition returns a pointer to the condition of the
+// given type, or nil if no such condition exists.
+func FindClusterIngressCondition(status *ClusterIngressStatus, t ClusterIngressConditionType) *ClusterIngressCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == t {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// RemoveClusterIngressCondition removes the condition of the given type, if
+// present.
+func RemoveClusterIngressCondition(status *ClusterIngressStatus, t ClusterIngressConditionType) {
+	conditions := make([]ClusterIngressCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		if c.Type != t {
+			conditions = append(conditions, c)
+		}
+	}
+	status.Conditions = conditions
+}
+
+// IsClusterIngressConditionTrue returns true if the condition of the given
+// type is present and has status True.
+func IsClusterIngressConditionTrue(status *ClusterIngressStatus, t ClusterIngressConditionType) bool {
+	condition := FindClusterIngressCondition(status, t)
+	return condition != nil && condition.Status == ConditionTrue
+}
@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestSetClusterIngressConditionSetsTransitionTimeOnChange(t *testing.T) {
+	status := &ClusterIngressStatus{}
+
+	SetClusterIngressCondition(status, ClusterIngressCondition{
+		Type:   ClusterIngressAvailable,
+		Status: ConditionFalse,
+		Reason: "RouterNotReady",
+	})
+	first := FindClusterIngressCondition(status, ClusterIngressAvailable)
+	if first == nil {
+		t.Fatalf("expected Available condition to be set")
+	}
+	firstTransition := first.LastTransitionTime
+
+	// Updating with the same status should not move the transition time.
+	SetClusterIngressCondition(status, ClusterIngressCondition{
+		Type:   ClusterIngressAvailable,
+		Status: ConditionFalse,
+		Reason: "RouterNotReady",
+	})
+	unchanged := FindClusterIngressCondition(status, ClusterIngressAvailable)
+	if unchanged.LastTransitionTime != firstTransition {
+		t.Errorf("expected LastTransitionTime to be unchanged when status doesn't change")
+	}
+
+	// Flipping the status should move the transition time and update reason.
+	SetClusterIngressCondition(status, ClusterIngressCondition{
+		Type:   ClusterIngressAvailable,
+		Status: ConditionTrue,
+		Reason: "RouterReady",
+	})
+	changed := FindClusterIngressCondition(status, ClusterIngressAvailable)
+	if changed.Status != ConditionTrue {
+		t.Errorf("expected status to be updated to True, got %s", changed.Status)
+	}
+	if changed.Reason != "RouterReady" {
+		t.Errorf("expected reason to be updated, got %s", changed.Reason)
+	}
+	if !changed.LastTransitionTime.After(firstTransition.Time) {
+		t.Errorf("expected LastTransitionTime to move forward when status changes")
+	}
+}
+
+func TestRemoveClusterIngressCondition(t *testing.T) {
+	status := &ClusterIngressStatus{}
+	SetClusterIngressCondition(status, ClusterIngressCondition{Type: ClusterIngressDegraded, Status: ConditionTrue})
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(status.Conditions))
+	}
+
+	RemoveClusterIngressCondition(status, ClusterIngressDegraded)
+	if FindClusterIngressCondition(status, ClusterIngressDegraded) != nil {
+		t.Errorf("expected Degraded condition to be removed")
+	}
+}
+
+func TestIsClusterIngressConditionTrue(t *testing.T) {
+	status := &ClusterIngressStatus{}
+	if IsClusterIngressConditionTrue(status, ClusterIngressAvailable) {
+		t.Errorf("expected false for missing condition")
+	}
+
+	SetClusterIngressCondition(status, ClusterIngressCondition{Type: ClusterIngressAvailable, Status: ConditionTrue})
+	if !IsClusterIngressConditionTrue(status, ClusterIngressAvailable) {
+		t.Errorf("expected true once condition is set to True")
+	}
+}
@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidateShard checks a shard ClusterIngress's selectors against every
+// other shard in others, returning an error if any of them could both admit
+// the same route. It is a no-op for a non-sharded ClusterIngress, since the
+// default router isn't scoped by selectors in the first place.
+func ValidateShard(ci *ClusterIngress, others []ClusterIngress) error {
+	if !ci.IsShard() {
+		return nil
+	}
+	for i := range others {
+		other := others[i]
+		if other.Name == ci.Name || !other.IsShard() {
+			continue
+		}
+		if selectorsOverlap(ci.Spec.RouteSelector, other.Spec.RouteSelector) &&
+			selectorsOverlap(ci.Spec.NamespaceSelector, other.Spec.NamespaceSelector) {
+			return fmt.Errorf("shard %q's selectors overlap with shard %q", ci.Name, other.Name)
+		}
+	}
+	return nil
+}
+
+// selectorsOverlap reports whether some route could satisfy both a and b at
+// once. A nil selector matches everything, so it overlaps with anything.
+// MatchExpressions aren't analyzed precisely; a selector using them is
+// conservatively treated as overlapping with everything, so an ambiguous
+// case fails validation rather than silently admitting a conflicting shard.
+func selectorsOverlap(a, b *metav1.LabelSelector) bool {
+	if a == nil || b == nil {
+		return true
+	}
+	if len(a.MatchExpressions) > 0 || len(b.MatchExpressions) > 0 {
+		return true
+	}
+	for k, av := range a.MatchLabels {
+		if bv, ok := b.MatchLabels[k]; ok && av != bv {
+			return false
+		}
+	}
+	return true
+}
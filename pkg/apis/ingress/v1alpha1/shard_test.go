@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func shardCI(name string, routeLabels, nsLabels map[string]string) ClusterIngress {
+	ci := ClusterIngress{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if routeLabels != nil {
+		ci.Spec.RouteSelector = &metav1.LabelSelector{MatchLabels: routeLabels}
+	}
+	if nsLabels != nil {
+		ci.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: nsLabels}
+	}
+	return ci
+}
+
+func TestValidateShardRejectsOverlappingSelectors(t *testing.T) {
+	a := shardCI("a", map[string]string{"team": "payments"}, map[string]string{"env": "prod"})
+	b := shardCI("b", map[string]string{"team": "payments"}, map[string]string{"env": "prod"})
+
+	if err := ValidateShard(&a, []ClusterIngress{a, b}); err == nil {
+		t.Fatal("expected identical shard selectors to be rejected as overlapping")
+	}
+}
+
+func TestValidateShardAllowsDisjointSelectors(t *testing.T) {
+	a := shardCI("a", map[string]string{"team": "payments"}, nil)
+	b := shardCI("b", map[string]string{"team": "checkout"}, nil)
+
+	if err := ValidateShard(&a, []ClusterIngress{a, b}); err != nil {
+		t.Fatalf("expected disjoint shard selectors to be allowed, got: %v", err)
+	}
+}
+
+func TestValidateShardIgnoresNonShards(t *testing.T) {
+	a := shardCI("a", map[string]string{"team": "payments"}, nil)
+	defaultCI := ClusterIngress{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+
+	if err := ValidateShard(&a, []ClusterIngress{a, defaultCI}); err != nil {
+		t.Fatalf("expected the non-sharded default ClusterIngress to be ignored, got: %v", err)
+	}
+	if err := ValidateShard(&defaultCI, []ClusterIngress{a, defaultCI}); err != nil {
+		t.Fatalf("expected ValidateShard to no-op for a non-sharded ClusterIngress, got: %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterIngress describes a cluster ingress operator configuration.
+// The operator uses a ClusterIngress to provision and manage a router
+// (and any supporting resources) that satisfies the configuration.
+type ClusterIngress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterIngressSpec   `json:"spec"`
+	Status ClusterIngressStatus `json:"status,omitempty"`
+}
+
+// ClusterIngressList is a collection of ClusterIngress resources.
+type ClusterIngressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterIngress `json:"items"`
+}
+
+// ClusterIngressSpec is the specification of the desired behavior of a
+// ClusterIngress.
+type ClusterIngressSpec struct {
+	// IngressDomain is the domain to serve ingress traffic for, and is the
+	// suffix that will be used for routes created by this ClusterIngress. If
+	// unspecified, the ingress controller's default domain is used.
+	//
+	// +optional
+	IngressDomain *string `json:"ingressDomain,omitempty"`
+
+	// NamespaceSelector restricts the set of namespaces from which routes may
+	// be admitted by this ClusterIngress. If nil, all namespaces are
+	// considered.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// RouteSelector restricts the set of routes, by label, that may be
+	// admitted by this ClusterIngress. If nil, all routes in the selected
+	// namespaces are considered.
+	//
+	// Setting RouteSelector and/or NamespaceSelector turns this ClusterIngress
+	// into a shard: an isolated router with its own dedicated namespace,
+	// rather than sharing the cluster's default router. See IsShard.
+	//
+	// +optional
+	RouteSelector *metav1.LabelSelector `json:"routeSelector,omitempty"`
+
+	// HighAvailability describes how the router is made available to
+	// clients. If unset, UserDefinedClusterIngressHA is used on every
+	// platform and no Service is provisioned; see HAType.
+	//
+	// +optional
+	HighAvailability *ClusterIngressHighAvailability `json:"highAvailability,omitempty"`
+
+	// Replicas is the desired number of router replicas. Ignored when the
+	// router is deployed as a DaemonSet.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// DefaultCertificateSecret is the name of a secret in the operator's
+	// namespace containing the default certificate served by the router for
+	// routes that don't specify their own.
+	//
+	// +optional
+	DefaultCertificateSecret *string `json:"defaultCertificateSecret,omitempty"`
+
+	// IngressController selects the name of the IngressBackend that
+	// provisions this ClusterIngress's data plane. If unset,
+	// DefaultIngressController is used.
+	//
+	// +optional
+	IngressController *string `json:"ingressController,omitempty"`
+}
+
+// DefaultIngressController is the IngressBackend used when
+// ClusterIngressSpec.IngressController is unset: the operator's own
+// HAProxy-based openshift-router.
+const DefaultIngressController = "openshift-router"
+
+// IngressControllerName returns the name of the IngressBackend that should
+// provision this ClusterIngress, defaulting to DefaultIngressController when
+// unset.
+func (ci *ClusterIngress) IngressControllerName() string {
+	if ci.Spec.IngressController == nil || *ci.Spec.IngressController == "" {
+		return DefaultIngressController
+	}
+	return *ci.Spec.IngressController
+}
+
+// HAType returns the effective HA type for the ClusterIngress, defaulting
+// to UserDefinedClusterIngressHA (no Service provisioned) when unset.
+func (ci *ClusterIngress) HAType() ClusterIngressHAType {
+	if ci.Spec.HighAvailability == nil {
+		return UserDefinedClusterIngressHA
+	}
+	return ci.Spec.HighAvailability.Type
+}
+
+// IsShard reports whether this ClusterIngress is an isolated shard with its
+// own dedicated router namespace, rather than sharing the cluster's default
+// router namespace. A ClusterIngress becomes a shard by restricting the
+// routes it admits via RouteSelector and/or NamespaceSelector.
+func (ci *ClusterIngress) IsShard() bool {
+	return ci.Spec.RouteSelector != nil || ci.Spec.NamespaceSelector != nil
+}
+
+// ShardNamespace returns the dedicated namespace a shard's router resources
+// are provisioned in. It returns the empty string for a non-sharded
+// ClusterIngress, which uses the cluster's shared default router namespace
+// instead.
+func (ci *ClusterIngress) ShardNamespace() string {
+	if !ci.IsShard() {
+		return ""
+	}
+	return fmt.Sprintf("openshift-ingress-%s", ci.Name)
+}
+
+// ClusterIngressHighAvailability describes how the router for a
+// ClusterIngress is exposed to clients.
+type ClusterIngressHighAvailability struct {
+	// Type is the HA provider that will be used to ensure the router is
+	// reachable.
+	Type ClusterIngressHAType `json:"type"`
+}
+
+// ClusterIngressHAType is a type of HA provider.
+type ClusterIngressHAType string
+
+const (
+	// CloudClusterIngressHA provisions a cloud load balancer Service in
+	// front of the router DaemonSet. Only valid on cloud platforms.
+	CloudClusterIngressHA ClusterIngressHAType = "Cloud"
+
+	// NodePortClusterIngressHA provisions a NodePort Service in front of the
+	// router DaemonSet, suitable for bare-metal and on-prem installs that
+	// front the cluster with an external load balancer of their own.
+	NodePortClusterIngressHA ClusterIngressHAType = "NodePort"
+
+	// HostNetworkClusterIngressHA binds the router directly to host ports on
+	// every node running it, via the DaemonSet's pod spec. No Service is
+	// created.
+	HostNetworkClusterIngressHA ClusterIngressHAType = "HostNetwork"
+
+	// UserDefinedClusterIngressHA indicates the administrator is managing
+	// the router's external availability themselves; the operator creates
+	// no Service at all.
+	UserDefinedClusterIngressHA ClusterIngressHAType = "UserDefined"
+)
+
+// ClusterIngressStatus is information about the current status of a
+// ClusterIngress.
+type ClusterIngressStatus struct {
+	// Conditions is a list of conditions and their status as last observed
+	// by the operator.
+	//
+	// +optional
+	Conditions []ClusterIngressCondition `json:"conditions,omitempty"`
+}
+
+// ClusterIngressConditionType is a type of condition associated with a
+// ClusterIngress.
+type ClusterIngressConditionType string
+
+const (
+	// ClusterIngressAvailable indicates the router for the ClusterIngress is
+	// available to serve traffic.
+	ClusterIngressAvailable ClusterIngressConditionType = "Available"
+
+	// ClusterIngressProgressing indicates the operator is actively working
+	// to reconcile the ClusterIngress towards its desired state.
+	ClusterIngressProgressing ClusterIngressConditionType = "Progressing"
+
+	// ClusterIngressDegraded indicates the ClusterIngress is not fully
+	// functional, e.g. because the last reconcile attempt failed.
+	ClusterIngressDegraded ClusterIngressConditionType = "Degraded"
+)
+
+// ConditionStatus is the status of a condition, following the conventions
+// of k8s.io/api/core/v1.ConditionStatus.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// ClusterIngressCondition represents the state of a particular aspect of a
+// ClusterIngress at a point in time.
+type ClusterIngressCondition struct {
+	// Type is the type of the condition.
+	Type ClusterIngressConditionType `json:"type"`
+
+	// Status is the status of the condition, one of True, False, Unknown.
+	Status ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from
+	// one status to another.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief, machine-readable explanation for the condition's
+	// last transition.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable explanation containing details about the
+	// last transition.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import "testing"
+
+func TestClusterIngressHAType(t *testing.T) {
+	cases := []struct {
+		name string
+		ci   *ClusterIngress
+		want ClusterIngressHAType
+	}{
+		{
+			name: "unset defaults to user defined",
+			ci:   &ClusterIngress{},
+			want: UserDefinedClusterIngressHA,
+		},
+		{
+			name: "cloud",
+			ci: &ClusterIngress{
+				Spec: ClusterIngressSpec{
+					HighAvailability: &ClusterIngressHighAvailability{
+						Type: CloudClusterIngressHA,
+					},
+				},
+			},
+			want: CloudClusterIngressHA,
+		},
+		{
+			name: "host network",
+			ci: &ClusterIngress{
+				Spec: ClusterIngressSpec{
+					HighAvailability: &ClusterIngressHighAvailability{
+						Type: HostNetworkClusterIngressHA,
+					},
+				},
+			},
+			want: HostNetworkClusterIngressHA,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ci.HAType(); got != tc.want {
+				t.Errorf("HAType() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClusterIngressIngressControllerName(t *testing.T) {
+	nginx := "nginx-ingress"
+	cases := []struct {
+		name string
+		ci   *ClusterIngress
+		want string
+	}{
+		{
+			name: "unset defaults to openshift-router",
+			ci:   &ClusterIngress{},
+			want: DefaultIngressController,
+		},
+		{
+			name: "explicit backend",
+			ci: &ClusterIngress{
+				Spec: ClusterIngressSpec{IngressController: &nginx},
+			},
+			want: "nginx-ingress",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ci.IngressControllerName(); got != tc.want {
+				t.Errorf("IngressControllerName() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Package asset models the resources the operator manages as a dependency
+// graph, following the design used by openshift/installer's pkg/asset: each
+// asset knows what it depends on and how to render itself once those
+// dependencies are available, and a Store walks the graph generating each
+// asset exactly once.
+package asset
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Asset is a single node in the dependency graph of resources the operator
+// manages.
+type Asset interface {
+	// Name returns a human-readable name for the asset, used in logs and
+	// error messages.
+	Name() string
+
+	// Dependencies returns the assets that must be generated before this
+	// one.
+	Dependencies() []Asset
+
+	// Generate renders the asset, given its already-generated dependencies.
+	Generate(Parents) error
+}
+
+// Hashable is implemented by assets whose rendered form can be digested, so
+// a Store can tell whether the asset changed since it was last applied to
+// the cluster and skip reapplying it when it didn't.
+type Hashable interface {
+	Asset
+
+	// Hash returns a digest of the asset's current rendered content.
+	Hash() (string, error)
+}
+
+// Parents is the set of already-generated dependencies passed to an asset's
+// Generate, indexed by concrete type.
+type Parents map[reflect.Type]Asset
+
+// Get retrieves the parent with the same concrete type as into and copies
+// it into into. It panics if no such parent was generated, since that means
+// the asset didn't declare it via Dependencies - a programming error.
+func (p Parents) Get(into Asset) {
+	t := reflect.TypeOf(into)
+	parent, ok := p[t]
+	if !ok {
+		panic(fmt.Sprintf("%s is not a declared dependency", t))
+	}
+	reflect.ValueOf(into).Elem().Set(reflect.ValueOf(parent).Elem())
+}
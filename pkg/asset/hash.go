@@ -0,0 +1,19 @@
+package asset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"sigs.k8s.io/yaml"
+)
+
+// HashObject renders obj as YAML and returns a hex-encoded sha256 digest of
+// it, for assets implementing Hashable.
+func HashObject(obj interface{}) (string, error) {
+	bs, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// ClusterRole is the asset for the ClusterRole routers need. It is cluster
+// scoped and has no dependencies.
+type ClusterRole struct {
+	Factory *manifests.Factory
+
+	Object *rbacv1.ClusterRole
+}
+
+func (a *ClusterRole) Name() string { return "Router ClusterRole" }
+
+func (a *ClusterRole) Dependencies() []asset.Asset { return nil }
+
+func (a *ClusterRole) Generate(asset.Parents) error {
+	cr, err := a.Factory.RouterClusterRole()
+	if err != nil {
+		return err
+	}
+	a.Object = cr
+	return nil
+}
+
+func (a *ClusterRole) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
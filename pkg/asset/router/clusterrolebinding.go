@@ -0,0 +1,84 @@
+package router
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// ClusterRoleBinding is the asset granting a router's ServiceAccount the
+// router ClusterRole. It depends on both.
+//
+// ClusterRoleBinding is cluster scoped, so a shard gets its own, named
+// distinctly from the shared default's, to avoid colliding with (or being
+// overwritten by) another shard's binding.
+type ClusterRoleBinding struct {
+	Factory        *manifests.Factory
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *rbacv1.ClusterRoleBinding
+}
+
+func (a *ClusterRoleBinding) Name() string {
+	if a.ClusterIngress != nil {
+		return fmt.Sprintf("Router ClusterRoleBinding (%s)", a.ClusterIngress.Name)
+	}
+	return "Router ClusterRoleBinding"
+}
+
+func (a *ClusterRoleBinding) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&ClusterRole{Factory: a.Factory},
+		&ServiceAccount{Factory: a.Factory, ClusterIngress: a.ClusterIngress},
+	}
+}
+
+func (a *ClusterRoleBinding) Generate(parents asset.Parents) error {
+	cr := &ClusterRole{}
+	parents.Get(cr)
+	sa := &ServiceAccount{}
+	parents.Get(sa)
+
+	crb, err := a.Factory.RouterClusterRoleBinding()
+	if err != nil {
+		return err
+	}
+	crb.Name = shardName(crb.Name, a.ClusterIngress)
+	crb.RoleRef.Name = cr.Object.Name
+	for i := range crb.Subjects {
+		crb.Subjects[i].Name = sa.Object.Name
+		crb.Subjects[i].Namespace = sa.Object.Namespace
+	}
+	a.Object = crb
+	return nil
+}
+
+func (a *ClusterRoleBinding) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
+
+// ClusterRoleBindingName returns the name a ClusterRoleBinding asset for ci
+// would render, without going through the full asset graph. It is used to
+// look up a shard's binding for deletion once its ClusterIngress is gone and
+// there's nothing left to Generate from.
+func ClusterRoleBindingName(factory *manifests.Factory, ci *ingressv1alpha1.ClusterIngress) (string, error) {
+	crb, err := factory.RouterClusterRoleBinding()
+	if err != nil {
+		return "", err
+	}
+	return shardName(crb.Name, ci), nil
+}
+
+// shardName returns base unchanged for the shared default resources, or
+// base suffixed with the shard's name so per-shard cluster-scoped resources
+// don't collide with each other or the default.
+func shardName(base string, ci *ingressv1alpha1.ClusterIngress) string {
+	if ci == nil || !ci.IsShard() {
+		return base
+	}
+	return fmt.Sprintf("%s-%s", base, ci.Name)
+}
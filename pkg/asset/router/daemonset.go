@@ -0,0 +1,52 @@
+package router
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// DaemonSet is the asset for the router DaemonSet backing a single
+// ClusterIngress. It depends on ServiceAccount for the account it runs as.
+type DaemonSet struct {
+	Factory        *manifests.Factory
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *appsv1.DaemonSet
+}
+
+func (a *DaemonSet) Name() string {
+	return fmt.Sprintf("Router DaemonSet (%s)", a.ClusterIngress.Name)
+}
+
+func (a *DaemonSet) Dependencies() []asset.Asset {
+	return []asset.Asset{&ServiceAccount{Factory: a.Factory, ClusterIngress: a.ClusterIngress}}
+}
+
+func (a *DaemonSet) Generate(parents asset.Parents) error {
+	sa := &ServiceAccount{}
+	parents.Get(sa)
+
+	var ds *appsv1.DaemonSet
+	var err error
+	if a.ClusterIngress.HAType() == ingressv1alpha1.HostNetworkClusterIngressHA {
+		ds, err = a.Factory.RouterDaemonSetHostNetwork(a.ClusterIngress)
+	} else {
+		ds, err = a.Factory.RouterDaemonSet(a.ClusterIngress)
+	}
+	if err != nil {
+		return err
+	}
+	ds.Namespace = sa.Object.Namespace
+	ds.Spec.Template.Spec.ServiceAccountName = sa.Object.Name
+	a.Object = ds
+	return nil
+}
+
+func (a *DaemonSet) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
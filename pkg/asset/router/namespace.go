@@ -0,0 +1,65 @@
+// Package router provides the asset.Asset implementations for the resources
+// that make up a router: the namespace and RBAC scaffolding it runs under,
+// and the per-ClusterIngress DaemonSet and Service.
+package router
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// Namespace is the asset for the namespace router resources live in. It has
+// no dependencies.
+//
+// For the default, non-sharded ClusterIngress this is the cluster's shared
+// router namespace. For a shard, ClusterIngress is set and Namespace
+// renders that shard's own dedicated namespace instead, owned by the
+// shard so it can be deleted independently of every other shard.
+type Namespace struct {
+	Factory *manifests.Factory
+
+	// ClusterIngress is nil for the shared default router namespace, or the
+	// owning ClusterIngress for a shard's dedicated namespace.
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *corev1.Namespace
+}
+
+func (a *Namespace) Name() string {
+	if a.ClusterIngress != nil {
+		return fmt.Sprintf("Router Namespace (%s)", a.ClusterIngress.Name)
+	}
+	return "Router Namespace"
+}
+
+func (a *Namespace) Dependencies() []asset.Asset { return nil }
+
+func (a *Namespace) Generate(asset.Parents) error {
+	ns, err := a.Factory.RouterNamespace()
+	if err != nil {
+		return err
+	}
+	if a.ClusterIngress != nil && a.ClusterIngress.IsShard() {
+		ns.Name = a.ClusterIngress.ShardNamespace()
+		trueVar := true
+		ns.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: a.ClusterIngress.APIVersion,
+			Kind:       a.ClusterIngress.Kind,
+			Name:       a.ClusterIngress.Name,
+			UID:        a.ClusterIngress.UID,
+			Controller: &trueVar,
+		}}
+	}
+	a.Object = ns
+	return nil
+}
+
+func (a *Namespace) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
@@ -0,0 +1,73 @@
+package router
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// Service is the asset for the Service fronting a router's DaemonSet, for
+// the HA types that provision one. It depends on DaemonSet, both to own a
+// reference to it and to know which namespace it landed in.
+//
+// Object is nil after Generate when the ClusterIngress's HA type doesn't
+// provision a Service at all (HostNetwork, UserDefined).
+type Service struct {
+	Factory        *manifests.Factory
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *corev1.Service
+}
+
+func (a *Service) Name() string {
+	return fmt.Sprintf("Router Service (%s)", a.ClusterIngress.Name)
+}
+
+func (a *Service) Dependencies() []asset.Asset {
+	return []asset.Asset{&DaemonSet{Factory: a.Factory, ClusterIngress: a.ClusterIngress}}
+}
+
+func (a *Service) Generate(parents asset.Parents) error {
+	ds := &DaemonSet{}
+	parents.Get(ds)
+
+	var svc *corev1.Service
+	var err error
+	switch a.ClusterIngress.HAType() {
+	case ingressv1alpha1.CloudClusterIngressHA:
+		svc, err = a.Factory.RouterServiceCloud(a.ClusterIngress)
+	case ingressv1alpha1.NodePortClusterIngressHA:
+		svc, err = a.Factory.RouterServiceNodePort(a.ClusterIngress)
+	}
+	if err != nil {
+		return err
+	}
+	if svc == nil {
+		a.Object = nil
+		return nil
+	}
+
+	svc.Namespace = ds.Object.Namespace
+	trueVar := true
+	svc.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: ds.Object.APIVersion,
+		Kind:       ds.Object.Kind,
+		Name:       ds.Object.Name,
+		UID:        ds.Object.UID,
+		Controller: &trueVar,
+	}}
+	a.Object = svc
+	return nil
+}
+
+func (a *Service) Hash() (string, error) {
+	if a.Object == nil {
+		return "", nil
+	}
+	return asset.HashObject(a.Object)
+}
@@ -0,0 +1,43 @@
+package router
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// ServiceAccount is the asset for the ServiceAccount routers run as. It
+// depends on Namespace for the namespace routers live in, and shares its
+// ClusterIngress (nil for the default, set for a shard) so the two always
+// land in the same namespace.
+type ServiceAccount struct {
+	Factory        *manifests.Factory
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *corev1.ServiceAccount
+}
+
+func (a *ServiceAccount) Name() string { return "Router ServiceAccount" }
+
+func (a *ServiceAccount) Dependencies() []asset.Asset {
+	return []asset.Asset{&Namespace{Factory: a.Factory, ClusterIngress: a.ClusterIngress}}
+}
+
+func (a *ServiceAccount) Generate(parents asset.Parents) error {
+	ns := &Namespace{}
+	parents.Get(ns)
+
+	sa, err := a.Factory.RouterServiceAccount()
+	if err != nil {
+		return err
+	}
+	sa.Namespace = ns.Object.Name
+	a.Object = sa
+	return nil
+}
+
+func (a *ServiceAccount) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
@@ -0,0 +1,28 @@
+// Package simplebackend provides the asset.Asset implementations shared by
+// every IngressBackend that deploys as a single Deployment and Service per
+// ClusterIngress into one shared namespace, with no sharding or custom RBAC
+// - the shape nginx-ingress and apisix both share. A backend using it
+// supplies a Config binding each asset to the manifests.Factory methods
+// that render its actual resources.
+package simplebackend
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+)
+
+// Config supplies the manifests.Factory methods a simplebackend asset graph
+// renders its resources from, plus the DisplayName used in asset names and
+// log/error messages.
+type Config struct {
+	// DisplayName identifies the backend in asset names and log/error
+	// messages, e.g. "nginx-ingress" or "apisix".
+	DisplayName string
+
+	Namespace      func() (*corev1.Namespace, error)
+	ServiceAccount func() (*corev1.ServiceAccount, error)
+	Deployment     func(ci *ingressv1alpha1.ClusterIngress) (*appsv1.Deployment, error)
+	Service        func(ci *ingressv1alpha1.ClusterIngress) (*corev1.Service, error)
+}
@@ -0,0 +1,41 @@
+package simplebackend
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+)
+
+// Deployment is the asset for the Deployment backing a single
+// ClusterIngress. It depends on ServiceAccount for the account it runs as.
+type Deployment struct {
+	Config         Config
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *appsv1.Deployment
+}
+
+func (a *Deployment) Name() string { return a.Config.DisplayName + " Deployment" }
+
+func (a *Deployment) Dependencies() []asset.Asset {
+	return []asset.Asset{&ServiceAccount{Config: a.Config}}
+}
+
+func (a *Deployment) Generate(parents asset.Parents) error {
+	sa := &ServiceAccount{}
+	parents.Get(sa)
+
+	d, err := a.Config.Deployment(a.ClusterIngress)
+	if err != nil {
+		return err
+	}
+	d.Namespace = sa.Object.Namespace
+	d.Spec.Template.Spec.ServiceAccountName = sa.Object.Name
+	a.Object = d
+	return nil
+}
+
+func (a *Deployment) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
@@ -0,0 +1,32 @@
+package simplebackend
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+)
+
+// Namespace is the asset for the namespace a simplebackend's resources live
+// in. It has no dependencies.
+type Namespace struct {
+	Config Config
+
+	Object *corev1.Namespace
+}
+
+func (a *Namespace) Name() string { return a.Config.DisplayName + " Namespace" }
+
+func (a *Namespace) Dependencies() []asset.Asset { return nil }
+
+func (a *Namespace) Generate(asset.Parents) error {
+	ns, err := a.Config.Namespace()
+	if err != nil {
+		return err
+	}
+	a.Object = ns
+	return nil
+}
+
+func (a *Namespace) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
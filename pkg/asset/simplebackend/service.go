@@ -0,0 +1,50 @@
+package simplebackend
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+)
+
+// Service is the asset for the load balancer Service fronting a
+// simplebackend's Deployment. It depends on Deployment, both to own a
+// reference to it and to know which namespace it landed in.
+type Service struct {
+	Config         Config
+	ClusterIngress *ingressv1alpha1.ClusterIngress
+
+	Object *corev1.Service
+}
+
+func (a *Service) Name() string { return a.Config.DisplayName + " Service" }
+
+func (a *Service) Dependencies() []asset.Asset {
+	return []asset.Asset{&Deployment{Config: a.Config, ClusterIngress: a.ClusterIngress}}
+}
+
+func (a *Service) Generate(parents asset.Parents) error {
+	d := &Deployment{}
+	parents.Get(d)
+
+	svc, err := a.Config.Service(a.ClusterIngress)
+	if err != nil {
+		return err
+	}
+	svc.Namespace = d.Object.Namespace
+	trueVar := true
+	svc.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: d.Object.APIVersion,
+		Kind:       d.Object.Kind,
+		Name:       d.Object.Name,
+		UID:        d.Object.UID,
+		Controller: &trueVar,
+	}}
+	a.Object = svc
+	return nil
+}
+
+func (a *Service) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
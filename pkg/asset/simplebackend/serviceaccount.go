@@ -0,0 +1,39 @@
+package simplebackend
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+)
+
+// ServiceAccount is the asset for the ServiceAccount a simplebackend's
+// Deployment runs as. It depends on Namespace for the namespace it lives
+// in.
+type ServiceAccount struct {
+	Config Config
+
+	Object *corev1.ServiceAccount
+}
+
+func (a *ServiceAccount) Name() string { return a.Config.DisplayName + " ServiceAccount" }
+
+func (a *ServiceAccount) Dependencies() []asset.Asset {
+	return []asset.Asset{&Namespace{Config: a.Config}}
+}
+
+func (a *ServiceAccount) Generate(parents asset.Parents) error {
+	ns := &Namespace{}
+	parents.Get(ns)
+
+	sa, err := a.Config.ServiceAccount()
+	if err != nil {
+		return err
+	}
+	sa.Namespace = ns.Object.Name
+	a.Object = sa
+	return nil
+}
+
+func (a *ServiceAccount) Hash() (string, error) {
+	return asset.HashObject(a.Object)
+}
@@ -0,0 +1,53 @@
+package asset
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Store generates an asset and its transitive dependencies, walking the
+// graph in topological order and generating each distinct asset type
+// exactly once per Store, regardless of how many other assets depend on it.
+type Store struct {
+	assets map[reflect.Type]Asset
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{assets: map[reflect.Type]Asset{}}
+}
+
+// Fetch generates the given asset, and any of its dependencies not already
+// cached in the Store, then returns it. Calling Fetch again with an asset
+// of the same concrete type returns the cached result without regenerating
+// it.
+func (s *Store) Fetch(a Asset) error {
+	return s.fetch(a, map[reflect.Type]bool{})
+}
+
+func (s *Store) fetch(a Asset, visiting map[reflect.Type]bool) error {
+	t := reflect.TypeOf(a)
+	if cached, ok := s.assets[t]; ok {
+		reflect.ValueOf(a).Elem().Set(reflect.ValueOf(cached).Elem())
+		return nil
+	}
+	if visiting[t] {
+		return fmt.Errorf("cycle detected while generating %s", a.Name())
+	}
+	visiting[t] = true
+
+	deps := a.Dependencies()
+	parents := make(Parents, len(deps))
+	for _, dep := range deps {
+		if err := s.fetch(dep, visiting); err != nil {
+			return err
+		}
+		parents[reflect.TypeOf(dep)] = s.assets[reflect.TypeOf(dep)]
+	}
+
+	if err := a.Generate(parents); err != nil {
+		return fmt.Errorf("failed to generate %s: %v", a.Name(), err)
+	}
+	s.assets[t] = a
+	return nil
+}
@@ -0,0 +1,79 @@
+package asset
+
+import "testing"
+
+// Store keys generation by concrete type, so the fixtures below use two
+// distinct types (fakeLeaf and fakeRoot) to exercise a real dependency edge.
+type fakeLeaf struct {
+	gens int
+}
+
+func (a *fakeLeaf) Name() string          { return "leaf" }
+func (a *fakeLeaf) Dependencies() []Asset { return nil }
+func (a *fakeLeaf) Generate(Parents) error {
+	a.gens++
+	return nil
+}
+
+type fakeRoot struct {
+	gens int
+}
+
+func (a *fakeRoot) Name() string          { return "root" }
+func (a *fakeRoot) Dependencies() []Asset { return []Asset{&fakeLeaf{}} }
+func (a *fakeRoot) Generate(parents Parents) error {
+	leaf := &fakeLeaf{}
+	parents.Get(leaf)
+	a.gens++
+	return nil
+}
+
+func TestStoreFetchGeneratesEachAssetOnce(t *testing.T) {
+	store := NewStore()
+
+	leaf := &fakeLeaf{}
+	if err := store.Fetch(leaf); err != nil {
+		t.Fatalf("Fetch(leaf): %v", err)
+	}
+	if leaf.gens != 1 {
+		t.Fatalf("expected leaf to generate once, got %d", leaf.gens)
+	}
+
+	root := &fakeRoot{}
+	if err := store.Fetch(root); err != nil {
+		t.Fatalf("Fetch(root): %v", err)
+	}
+	if root.gens != 1 {
+		t.Fatalf("expected root to generate once, got %d", root.gens)
+	}
+
+	// Refetching leaf must not regenerate it, and must copy the cached
+	// result's state into again rather than leaving it zero-valued.
+	again := &fakeLeaf{}
+	if err := store.Fetch(again); err != nil {
+		t.Fatalf("Fetch(leaf) again: %v", err)
+	}
+	if again.gens != 1 {
+		t.Fatalf("expected refetching a cached leaf type to return the cached result (gens=1), got %d", again.gens)
+	}
+}
+
+// cyclicA and cyclicB depend on each other, which Store.Fetch must reject
+// rather than recursing forever.
+type cyclicA struct{}
+type cyclicB struct{}
+
+func (a *cyclicA) Name() string           { return "a" }
+func (a *cyclicA) Dependencies() []Asset  { return []Asset{&cyclicB{}} }
+func (a *cyclicA) Generate(Parents) error { return nil }
+
+func (b *cyclicB) Name() string           { return "b" }
+func (b *cyclicB) Dependencies() []Asset  { return []Asset{&cyclicA{}} }
+func (b *cyclicB) Generate(Parents) error { return nil }
+
+func TestStoreFetchDetectsCycles(t *testing.T) {
+	store := NewStore()
+	if err := store.Fetch(&cyclicA{}); err == nil {
+		t.Fatal("expected Fetch to detect the a->b->a cycle, got nil error")
+	}
+}
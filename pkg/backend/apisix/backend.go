@@ -0,0 +1,32 @@
+// Package apisix is an IngressBackend that provisions Apache APISIX as a
+// ClusterIngress's data plane. Like nginxingress, it doesn't support
+// sharding or custom RBAC the way openshiftrouter does; it deploys one
+// Deployment and Service per ClusterIngress into a single shared namespace,
+// via the generic pkg/backend/simplebackend.
+package apisix
+
+import (
+	"github.com/openshift/cluster-ingress-operator/pkg/asset/simplebackend"
+	"github.com/openshift/cluster-ingress-operator/pkg/backend"
+	simplebackendBackend "github.com/openshift/cluster-ingress-operator/pkg/backend/simplebackend"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// Name is the backend name ClusterIngressSpec.IngressController selects
+// this backend with.
+const Name = "apisix"
+
+func init() {
+	backend.Register(Name, New)
+}
+
+// New returns an IngressBackend rendering apisix resources from f.
+func New(f *manifests.Factory) backend.IngressBackend {
+	return simplebackendBackend.New(simplebackend.Config{
+		DisplayName:    Name,
+		Namespace:      f.ApisixNamespace,
+		ServiceAccount: f.ApisixServiceAccount,
+		Deployment:     f.ApisixDeployment,
+		Service:        f.ApisixService,
+	})
+}
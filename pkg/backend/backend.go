@@ -0,0 +1,55 @@
+// Package backend lets the operator provision more than one kind of
+// ingress data plane. An IngressBackend knows how to deploy and tear down
+// whatever it takes to satisfy a ClusterIngress - openshift-router today,
+// and other ingress controllers as they're added - without the reconciler
+// needing to know which one it's talking to.
+package backend
+
+import (
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// IngressBackend provisions and tears down the data plane for a
+// ClusterIngress.
+type IngressBackend interface {
+	// Name is the value ClusterIngressSpec.IngressController selects this
+	// backend with.
+	Name() string
+
+	// EnsureDeployed ensures the backend's resources exist and match ci's
+	// desired state.
+	EnsureDeployed(ci *ingressv1alpha1.ClusterIngress) error
+
+	// EnsureDeleted ensures the backend's resources for ci are removed.
+	EnsureDeleted(ci *ingressv1alpha1.ClusterIngress) error
+}
+
+// Constructor builds an IngressBackend bound to the given manifest Factory.
+// Backends register a Constructor rather than an IngressBackend instance,
+// since each Handler needs its own instance to hold the apply-if-changed
+// cache it tracks across reconciles.
+type Constructor func(f *manifests.Factory) IngressBackend
+
+var constructors = map[string]Constructor{}
+
+// Register makes a backend available under name, for later construction via
+// New. It is meant to be called from an init function in the package that
+// implements the backend, e.g.:
+//
+//	func init() {
+//		backend.Register("openshift-router", New)
+//	}
+func Register(name string, ctor Constructor) {
+	constructors[name] = ctor
+}
+
+// New constructs the backend registered under name, or returns false if no
+// backend was registered with that name.
+func New(name string, f *manifests.Factory) (IngressBackend, bool) {
+	ctor, ok := constructors[name]
+	if !ok {
+		return nil, false
+	}
+	return ctor(f), true
+}
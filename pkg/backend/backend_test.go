@@ -0,0 +1,34 @@
+package backend
+
+import (
+	"testing"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+type fakeBackend struct{ name string }
+
+func (b *fakeBackend) Name() string                                         { return b.name }
+func (b *fakeBackend) EnsureDeployed(*ingressv1alpha1.ClusterIngress) error { return nil }
+func (b *fakeBackend) EnsureDeleted(*ingressv1alpha1.ClusterIngress) error  { return nil }
+
+func TestNewConstructsRegisteredBackend(t *testing.T) {
+	Register("fake-backend", func(f *manifests.Factory) IngressBackend {
+		return &fakeBackend{name: "fake-backend"}
+	})
+
+	b, ok := New("fake-backend", nil)
+	if !ok {
+		t.Fatal("expected New to find the registered backend")
+	}
+	if got := b.Name(); got != "fake-backend" {
+		t.Errorf("Name() = %q, want %q", got, "fake-backend")
+	}
+}
+
+func TestNewReportsUnregisteredBackend(t *testing.T) {
+	if _, ok := New("does-not-exist", nil); ok {
+		t.Error("expected New to report an unregistered backend as not found")
+	}
+}
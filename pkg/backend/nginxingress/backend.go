@@ -0,0 +1,34 @@
+// Package nginxingress is an IngressBackend that provisions ingress-nginx
+// as a ClusterIngress's data plane, for administrators who'd rather run a
+// widely-used community ingress controller than the operator's own
+// openshift-router. It doesn't support sharding or custom RBAC the way
+// openshiftrouter does; it deploys one Deployment and Service per
+// ClusterIngress into a single shared namespace, via the generic
+// pkg/backend/simplebackend.
+package nginxingress
+
+import (
+	"github.com/openshift/cluster-ingress-operator/pkg/asset/simplebackend"
+	"github.com/openshift/cluster-ingress-operator/pkg/backend"
+	simplebackendBackend "github.com/openshift/cluster-ingress-operator/pkg/backend/simplebackend"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// Name is the backend name ClusterIngressSpec.IngressController selects
+// this backend with.
+const Name = "nginx-ingress"
+
+func init() {
+	backend.Register(Name, New)
+}
+
+// New returns an IngressBackend rendering nginx-ingress resources from f.
+func New(f *manifests.Factory) backend.IngressBackend {
+	return simplebackendBackend.New(simplebackend.Config{
+		DisplayName:    Name,
+		Namespace:      f.NginxIngressNamespace,
+		ServiceAccount: f.NginxIngressServiceAccount,
+		Deployment:     f.NginxIngressDeployment,
+		Service:        f.NginxIngressService,
+	})
+}
@@ -0,0 +1,428 @@
+// Package openshiftrouter is the default IngressBackend: the operator's own
+// HAProxy-based openshift-router, deployed as a DaemonSet with an HA-type
+// dependent Service in front of it, as it was before backends became
+// pluggable.
+package openshiftrouter
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset/router"
+	"github.com/openshift/cluster-ingress-operator/pkg/backend"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Name is the backend name ClusterIngressSpec.IngressController selects
+// this backend with; it is also ingressv1alpha1.DefaultIngressController.
+const Name = "openshift-router"
+
+func init() {
+	backend.Register(Name, New)
+}
+
+// haTypeAnnotation records the ClusterIngressHAType the backend last
+// reconciled, so that EnsureDeployed can detect when an administrator
+// switches HA types on an existing ClusterIngress and clean up the Service
+// left behind by the old one.
+const haTypeAnnotation = "ingress.openshift.io/ha-type"
+
+// Backend is the openshift-router IngressBackend.
+type Backend struct {
+	Factory *manifests.Factory
+
+	// hashesMu guards hashes.
+	hashesMu sync.Mutex
+
+	// hashes records the digest of the last asset rendering applied to the
+	// cluster under a given key, so EnsureDeployed can skip a Create/Update
+	// round trip when an asset's generated content hasn't changed since the
+	// last reconcile.
+	hashes map[string]string
+}
+
+// New returns a Backend rendering resources from f.
+func New(f *manifests.Factory) backend.IngressBackend {
+	return &Backend{Factory: f}
+}
+
+func (b *Backend) Name() string { return Name }
+
+// applyIfChanged hashes the given asset and compares it against the hash
+// recorded the last time something was applied under key. If they match,
+// apply is skipped entirely; otherwise apply runs and, on success, the new
+// hash is recorded.
+func (b *Backend) applyIfChanged(key string, a asset.Hashable, apply func() error) error {
+	hash, err := a.Hash()
+	if err != nil {
+		return fmt.Errorf("couldn't hash %s: %v", a.Name(), err)
+	}
+
+	b.hashesMu.Lock()
+	if b.hashes == nil {
+		b.hashes = map[string]string{}
+	}
+	unchanged := hash != "" && b.hashes[key] == hash
+	b.hashesMu.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	b.hashesMu.Lock()
+	b.hashes[key] = hash
+	b.hashesMu.Unlock()
+	return nil
+}
+
+// EnsureDeployed ensures the namespace, RBAC, DaemonSet, and Service for ci
+// exist and match its desired state.
+func (b *Backend) EnsureDeployed(ci *ingressv1alpha1.ClusterIngress) error {
+	if err := b.ensureRouterNamespace(ci); err != nil {
+		return err
+	}
+	return b.ensureRouterForIngress(ci)
+}
+
+// EnsureDeleted ensures any router resources provisioned for ci are
+// removed.
+func (b *Backend) EnsureDeleted(ci *ingressv1alpha1.ClusterIngress) error {
+	ds, err := b.Factory.RouterDaemonSet(ci)
+	if err != nil {
+		return fmt.Errorf("couldn't build DaemonSet object for deletion: %v", err)
+	}
+	if err := sdk.Delete(ds); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	for _, t := range []ingressv1alpha1.ClusterIngressHAType{ingressv1alpha1.CloudClusterIngressHA, ingressv1alpha1.NodePortClusterIngressHA} {
+		if err := b.ensureRouterServiceDeleted(ci, t); err != nil {
+			return err
+		}
+	}
+
+	if ci.IsShard() {
+		if err := b.ensureRouterShardDeleted(ci); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureRouterNamespace ensures all the necessary scaffolding exists for a
+// ClusterIngress's router, including a namespace and all RBAC setup. For the
+// default, non-sharded ClusterIngress this is the cluster's shared router
+// namespace; for a shard it is that shard's own dedicated namespace, so
+// isolated shards can be torn down independently of one another. The
+// resources are rendered through the router asset graph (pkg/asset/router)
+// rather than built and created ad hoc, so that each is generated exactly
+// once and reapplied only when its rendered content actually changed.
+func (b *Backend) ensureRouterNamespace(ci *ingressv1alpha1.ClusterIngress) error {
+	shard := routerShard(ci)
+	shardKey := ""
+	if shard != nil {
+		shardKey = "-" + shard.Name
+	}
+
+	store := asset.NewStore()
+
+	ns := &router.Namespace{Factory: b.Factory, ClusterIngress: shard}
+	if err := store.Fetch(ns); err != nil {
+		return err
+	}
+	if err := b.applyIfChanged("router-namespace"+shardKey, ns, func() error {
+		return ensureNamespace(ns.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure router namespace: %v", err)
+	}
+
+	sa := &router.ServiceAccount{Factory: b.Factory, ClusterIngress: shard}
+	if err := store.Fetch(sa); err != nil {
+		return err
+	}
+	if err := b.applyIfChanged("router-serviceaccount"+shardKey, sa, func() error {
+		return ensureServiceAccount(sa.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure router service account: %v", err)
+	}
+
+	// The ClusterRole's permissions don't depend on the namespace they're
+	// bound into, so it's shared across the default router and every shard.
+	cr := &router.ClusterRole{Factory: b.Factory}
+	if err := store.Fetch(cr); err != nil {
+		return err
+	}
+	if err := b.applyIfChanged("router-clusterrole", cr, func() error {
+		return ensureClusterRole(cr.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure router cluster role: %v", err)
+	}
+
+	crb := &router.ClusterRoleBinding{Factory: b.Factory, ClusterIngress: shard}
+	if err := store.Fetch(crb); err != nil {
+		return err
+	}
+	if err := b.applyIfChanged("router-clusterrolebinding"+shardKey, crb, func() error {
+		return ensureClusterRoleBinding(crb.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure router cluster role binding: %v", err)
+	}
+
+	return nil
+}
+
+// routerShard returns ci if it is a shard, so callers can thread "nil for
+// the default, ci for a shard" through the router asset graph, or nil if ci
+// uses the cluster's shared default router instead.
+func routerShard(ci *ingressv1alpha1.ClusterIngress) *ingressv1alpha1.ClusterIngress {
+	if ci.IsShard() {
+		return ci
+	}
+	return nil
+}
+
+func ensureNamespace(wanted *corev1.Namespace) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created router namespace %q", wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create router namespace %q: %v", wanted.Name, err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get router namespace %q: %v", wanted.Name, err)
+	}
+	existing.Labels = wanted.Labels
+	return sdk.Update(existing)
+}
+
+func ensureServiceAccount(wanted *corev1.ServiceAccount) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created router service account %s/%s", wanted.Namespace, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create router service account %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	// ServiceAccounts have nothing worth reconciling once created.
+	return nil
+}
+
+func ensureClusterRole(wanted *rbacv1.ClusterRole) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created router cluster role %q", wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create router cluster role: %v", err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get router cluster role %q: %v", wanted.Name, err)
+	}
+	existing.Rules = wanted.Rules
+	return sdk.Update(existing)
+}
+
+func ensureClusterRoleBinding(wanted *rbacv1.ClusterRoleBinding) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created router cluster role binding %q", wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create router cluster role binding: %v", err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get router cluster role binding %q: %v", wanted.Name, err)
+	}
+	existing.RoleRef = wanted.RoleRef
+	existing.Subjects = wanted.Subjects
+	return sdk.Update(existing)
+}
+
+// ensureRouterForIngress ensures the DaemonSet and Service for ci exist and
+// match its desired state, rendering them through the router asset graph so
+// that generating and hashing them is a single step shared with
+// ensureRouterNamespace's assets.
+func (b *Backend) ensureRouterForIngress(ci *ingressv1alpha1.ClusterIngress) error {
+	store := asset.NewStore()
+
+	// The router asset graph's ServiceAccount dependency must resolve to
+	// the same namespace/account ensureRouterNamespace already created.
+	sa := &router.ServiceAccount{Factory: b.Factory, ClusterIngress: routerShard(ci)}
+	if err := store.Fetch(sa); err != nil {
+		return err
+	}
+
+	dsAsset := &router.DaemonSet{Factory: b.Factory, ClusterIngress: ci}
+	if err := store.Fetch(dsAsset); err != nil {
+		return fmt.Errorf("couldn't build daemonset: %v", err)
+	}
+	// applyIfChanged skips ensureDaemonSet entirely once the DaemonSet's
+	// hash stabilizes, so the server-assigned UID it would otherwise pick
+	// up from the AlreadyExists branch never reaches dsAsset.Object on
+	// later reconciles. Service.Generate needs that UID for its owner
+	// reference, so populate it unconditionally, hash comparison or not.
+	if err := populateDaemonSetIdentity(dsAsset.Object); err != nil {
+		return fmt.Errorf("couldn't look up existing router daemonset: %v", err)
+	}
+	if err := b.applyIfChanged("router-daemonset-"+ci.Name, dsAsset, func() error {
+		return ensureDaemonSet(dsAsset.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure router daemonset: %v", err)
+	}
+
+	currentType := ci.HAType()
+	if previousType, ok := ci.Annotations[haTypeAnnotation]; ok && ingressv1alpha1.ClusterIngressHAType(previousType) != currentType {
+		if err := b.ensureRouterServiceDeleted(ci, ingressv1alpha1.ClusterIngressHAType(previousType)); err != nil {
+			return fmt.Errorf("couldn't remove %s service left over from HA type change: %v", previousType, err)
+		}
+	}
+
+	svcAsset := &router.Service{Factory: b.Factory, ClusterIngress: ci}
+	if err := store.Fetch(svcAsset); err != nil {
+		return fmt.Errorf("couldn't build service: %v", err)
+	}
+	if svcAsset.Object != nil {
+		if err := b.applyIfChanged("router-service-"+ci.Name, svcAsset, func() error {
+			return ensureService(svcAsset.Object)
+		}); err != nil {
+			return fmt.Errorf("couldn't ensure router service: %v", err)
+		}
+	}
+
+	if ci.Annotations == nil {
+		ci.Annotations = map[string]string{}
+	}
+	ci.Annotations[haTypeAnnotation] = string(currentType)
+
+	return nil
+}
+
+// populateDaemonSetIdentity looks up wanted's existing DaemonSet, if any,
+// and copies its server-assigned identity onto wanted. It is a no-op, not
+// an error, when the DaemonSet doesn't exist yet, since the subsequent
+// Create will assign that identity for the first time.
+func populateDaemonSetIdentity(wanted *appsv1.DaemonSet) error {
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("couldn't get daemonset %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	copyDaemonSetIdentity(wanted, existing)
+	return nil
+}
+
+// copyDaemonSetIdentity copies the server-assigned fields of existing onto
+// wanted, so that an update built from wanted (or an asset rendered from
+// it, like the router Service's owner reference) doesn't regress them back
+// to zero-valued.
+func copyDaemonSetIdentity(wanted, existing *appsv1.DaemonSet) {
+	wanted.UID = existing.UID
+	wanted.ResourceVersion = existing.ResourceVersion
+}
+
+func ensureDaemonSet(wanted *appsv1.DaemonSet) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created router daemonset %s/%s", wanted.Namespace, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create daemonset %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get daemonset %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	copyDaemonSetIdentity(wanted, existing)
+	existing.Spec = wanted.Spec
+	return sdk.Update(existing)
+}
+
+func ensureService(wanted *corev1.Service) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created router service %s/%s", wanted.Namespace, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create service %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get service %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	// Preserve the cluster-assigned ClusterIP and NodePorts; only the
+	// selector and ports we render are worth reconciling.
+	existing.Spec.Selector = wanted.Spec.Selector
+	existing.Spec.Ports = wanted.Spec.Ports
+	return sdk.Update(existing)
+}
+
+// ensureRouterServiceDeleted deletes the Service that was provisioned for
+// the given (now stale) HA type, if that type provisions one at all.
+func (b *Backend) ensureRouterServiceDeleted(ci *ingressv1alpha1.ClusterIngress, t ingressv1alpha1.ClusterIngressHAType) error {
+	var service *corev1.Service
+	var err error
+	switch t {
+	case ingressv1alpha1.CloudClusterIngressHA:
+		service, err = b.Factory.RouterServiceCloud(ci)
+	case ingressv1alpha1.NodePortClusterIngressHA:
+		service, err = b.Factory.RouterServiceNodePort(ci)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't build service for deletion: %v", err)
+	}
+	if err := sdk.Delete(service); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	logrus.Infof("deleted stale %s router service %s/%s", t, service.Namespace, service.Name)
+	return nil
+}
+
+// ensureRouterShardDeleted removes a shard's dedicated namespace and its
+// cluster-scoped ClusterRoleBinding, neither of which get cleaned up simply
+// by deleting the namespaced resources (DaemonSet, Service) inside it.
+func (b *Backend) ensureRouterShardDeleted(ci *ingressv1alpha1.ClusterIngress) error {
+	crbName, err := router.ClusterRoleBindingName(b.Factory, ci)
+	if err != nil {
+		return fmt.Errorf("couldn't determine shard cluster role binding name: %v", err)
+	}
+	crb := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: crbName}}
+	if err := sdk.Delete(crb); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("couldn't delete shard cluster role binding %q: %v", crbName, err)
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ci.ShardNamespace()}}
+	if err := sdk.Delete(ns); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("couldn't delete shard namespace %q: %v", ns.Name, err)
+	}
+	logrus.Infof("deleted shard namespace %q for clusteringress %q", ns.Name, ci.Name)
+	return nil
+}
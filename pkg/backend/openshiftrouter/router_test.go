@@ -0,0 +1,27 @@
+package openshiftrouter
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCopyDaemonSetIdentityPreservesServerAssignedFields(t *testing.T) {
+	wanted := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "router-default"}}
+	existing := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "router-default",
+		UID:             types.UID("abc-123"),
+		ResourceVersion: "42",
+	}}
+
+	copyDaemonSetIdentity(wanted, existing)
+
+	if wanted.UID != existing.UID {
+		t.Errorf("UID = %q, want %q", wanted.UID, existing.UID)
+	}
+	if wanted.ResourceVersion != existing.ResourceVersion {
+		t.Errorf("ResourceVersion = %q, want %q", wanted.ResourceVersion, existing.ResourceVersion)
+	}
+}
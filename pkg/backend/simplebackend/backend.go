@@ -0,0 +1,248 @@
+// Package simplebackend is a generic IngressBackend for data planes that
+// deploy as a single Deployment and Service per ClusterIngress into one
+// shared namespace, with no sharding or custom RBAC - the shape
+// nginx-ingress and apisix both share. Each of those backends is a thin
+// file that builds a simplebackend.Config from its own manifests.Factory
+// methods and calls New.
+package simplebackend
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	assetsimple "github.com/openshift/cluster-ingress-operator/pkg/asset/simplebackend"
+	"github.com/openshift/cluster-ingress-operator/pkg/backend"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Backend is the simplebackend IngressBackend, rendering its resources from
+// Config.
+type Backend struct {
+	Config assetsimple.Config
+
+	// hashesMu guards hashes.
+	hashesMu sync.Mutex
+
+	// hashes records the digest of the last asset rendering applied to the
+	// cluster under a given key, so EnsureDeployed can skip a Create/Update
+	// round trip when an asset's generated content hasn't changed since the
+	// last reconcile.
+	hashes map[string]string
+}
+
+// New returns a Backend rendering resources from cfg.
+func New(cfg assetsimple.Config) backend.IngressBackend {
+	return &Backend{Config: cfg}
+}
+
+func (b *Backend) Name() string { return b.Config.DisplayName }
+
+// applyIfChanged hashes the given asset and compares it against the hash
+// recorded the last time something was applied under key. If they match,
+// apply is skipped entirely; otherwise apply runs and, on success, the new
+// hash is recorded.
+func (b *Backend) applyIfChanged(key string, a asset.Hashable, apply func() error) error {
+	hash, err := a.Hash()
+	if err != nil {
+		return fmt.Errorf("couldn't hash %s: %v", a.Name(), err)
+	}
+
+	b.hashesMu.Lock()
+	if b.hashes == nil {
+		b.hashes = map[string]string{}
+	}
+	unchanged := hash != "" && b.hashes[key] == hash
+	b.hashesMu.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	b.hashesMu.Lock()
+	b.hashes[key] = hash
+	b.hashesMu.Unlock()
+	return nil
+}
+
+// EnsureDeployed ensures the namespace, ServiceAccount, Deployment, and
+// Service for ci exist and match its desired state.
+func (b *Backend) EnsureDeployed(ci *ingressv1alpha1.ClusterIngress) error {
+	store := asset.NewStore()
+
+	ns := &assetsimple.Namespace{Config: b.Config}
+	if err := store.Fetch(ns); err != nil {
+		return err
+	}
+	if err := b.applyIfChanged(b.Config.DisplayName+"-namespace", ns, func() error {
+		return ensureNamespace(b.Config.DisplayName, ns.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure %s namespace: %v", b.Config.DisplayName, err)
+	}
+
+	sa := &assetsimple.ServiceAccount{Config: b.Config}
+	if err := store.Fetch(sa); err != nil {
+		return err
+	}
+	if err := b.applyIfChanged(b.Config.DisplayName+"-serviceaccount", sa, func() error {
+		return ensureServiceAccount(b.Config.DisplayName, sa.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure %s service account: %v", b.Config.DisplayName, err)
+	}
+
+	d := &assetsimple.Deployment{Config: b.Config, ClusterIngress: ci}
+	if err := store.Fetch(d); err != nil {
+		return fmt.Errorf("couldn't build deployment: %v", err)
+	}
+	// applyIfChanged skips ensureDeployment entirely once the Deployment's
+	// hash stabilizes, so the server-assigned UID it would otherwise pick
+	// up from the AlreadyExists branch never reaches d.Object on later
+	// reconciles. Service.Generate needs that UID for its owner reference,
+	// so populate it unconditionally, hash comparison or not.
+	if err := populateDeploymentIdentity(d.Object); err != nil {
+		return fmt.Errorf("couldn't look up existing %s deployment: %v", b.Config.DisplayName, err)
+	}
+	if err := b.applyIfChanged(b.Config.DisplayName+"-deployment-"+ci.Name, d, func() error {
+		return ensureDeployment(b.Config.DisplayName, d.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure %s deployment: %v", b.Config.DisplayName, err)
+	}
+
+	svc := &assetsimple.Service{Config: b.Config, ClusterIngress: ci}
+	if err := store.Fetch(svc); err != nil {
+		return fmt.Errorf("couldn't build service: %v", err)
+	}
+	if err := b.applyIfChanged(b.Config.DisplayName+"-service-"+ci.Name, svc, func() error {
+		return ensureService(b.Config.DisplayName, svc.Object)
+	}); err != nil {
+		return fmt.Errorf("couldn't ensure %s service: %v", b.Config.DisplayName, err)
+	}
+
+	return nil
+}
+
+// EnsureDeleted ensures the Deployment and Service provisioned for ci are
+// removed. The shared namespace and service account are left in place,
+// since other ClusterIngresses using this backend may still need them.
+func (b *Backend) EnsureDeleted(ci *ingressv1alpha1.ClusterIngress) error {
+	d, err := b.Config.Deployment(ci)
+	if err != nil {
+		return fmt.Errorf("couldn't build deployment object for deletion: %v", err)
+	}
+	if err := sdk.Delete(d); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	svc, err := b.Config.Service(ci)
+	if err != nil {
+		return fmt.Errorf("couldn't build service object for deletion: %v", err)
+	}
+	if err := sdk.Delete(svc); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func ensureNamespace(displayName string, wanted *corev1.Namespace) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created %s namespace %q", displayName, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create %s namespace %q: %v", displayName, wanted.Name, err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get %s namespace %q: %v", displayName, wanted.Name, err)
+	}
+	existing.Labels = wanted.Labels
+	return sdk.Update(existing)
+}
+
+func ensureServiceAccount(displayName string, wanted *corev1.ServiceAccount) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created %s service account %s/%s", displayName, wanted.Namespace, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create %s service account %s/%s: %v", displayName, wanted.Namespace, wanted.Name, err)
+	}
+	// ServiceAccounts have nothing worth reconciling once created.
+	return nil
+}
+
+// populateDeploymentIdentity looks up wanted's existing Deployment, if any,
+// and copies its server-assigned identity onto wanted. It is a no-op, not
+// an error, when the Deployment doesn't exist yet, since the subsequent
+// Create will assign that identity for the first time.
+func populateDeploymentIdentity(wanted *appsv1.Deployment) error {
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("couldn't get deployment %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	copyDeploymentIdentity(wanted, existing)
+	return nil
+}
+
+// copyDeploymentIdentity copies the server-assigned fields of existing onto
+// wanted, so that an update built from wanted (or an asset rendered from
+// it, like the Service's owner reference) doesn't regress them back to
+// zero-valued.
+func copyDeploymentIdentity(wanted, existing *appsv1.Deployment) {
+	wanted.UID = existing.UID
+	wanted.ResourceVersion = existing.ResourceVersion
+}
+
+func ensureDeployment(displayName string, wanted *appsv1.Deployment) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created %s deployment %s/%s", displayName, wanted.Namespace, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create deployment %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get deployment %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	copyDeploymentIdentity(wanted, existing)
+	existing.Spec = wanted.Spec
+	return sdk.Update(existing)
+}
+
+func ensureService(displayName string, wanted *corev1.Service) error {
+	err := sdk.Create(wanted)
+	if err == nil {
+		logrus.Infof("created %s service %s/%s", displayName, wanted.Namespace, wanted.Name)
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create service %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	existing := wanted.DeepCopy()
+	if err := sdk.Get(existing); err != nil {
+		return fmt.Errorf("couldn't get service %s/%s: %v", wanted.Namespace, wanted.Name, err)
+	}
+	// Preserve the cluster-assigned ClusterIP and NodePorts; only the
+	// selector and ports we render are worth reconciling.
+	existing.Spec.Selector = wanted.Spec.Selector
+	existing.Spec.Ports = wanted.Spec.Ports
+	return sdk.Update(existing)
+}
@@ -0,0 +1,107 @@
+package simplebackend
+
+import (
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/asset"
+	assetsimple "github.com/openshift/cluster-ingress-operator/pkg/asset/simplebackend"
+)
+
+// fakeConfig mimics a manifests.Factory-backed Config that names its
+// Deployment/Service after the owning ClusterIngress, the way the real
+// nginx-ingress and apisix factory methods do.
+func fakeConfig() assetsimple.Config {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fake-ns"}}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "fake-sa"}}
+	return assetsimple.Config{
+		DisplayName:    "fake-backend",
+		Namespace:      func() (*corev1.Namespace, error) { return ns.DeepCopy(), nil },
+		ServiceAccount: func() (*corev1.ServiceAccount, error) { return sa.DeepCopy(), nil },
+		Deployment: func(ci *ingressv1alpha1.ClusterIngress) (*appsv1.Deployment, error) {
+			return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("fake-backend-%s", ci.Name)}}, nil
+		},
+		Service: func(ci *ingressv1alpha1.ClusterIngress) (*corev1.Service, error) {
+			return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("fake-backend-%s", ci.Name)}}, nil
+		},
+	}
+}
+
+// Regression test: EnsureDeployed builds a fresh asset.Store per call, and
+// Deployment/Service.Generate render whatever Config.Deployment/Service
+// return. If those ever went back to ignoring ci and returning a
+// statically-named object, every ClusterIngress on this backend would
+// collide on one Deployment/Service, and deleting one ClusterIngress would
+// tear down every other ClusterIngress sharing the backend.
+func TestDeploymentAndServiceAreNamedPerClusterIngress(t *testing.T) {
+	cfg := fakeConfig()
+	a := &ingressv1alpha1.ClusterIngress{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	b := &ingressv1alpha1.ClusterIngress{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+
+	fetchDeployment := func(ci *ingressv1alpha1.ClusterIngress) *assetsimple.Deployment {
+		store := asset.NewStore()
+		d := &assetsimple.Deployment{Config: cfg, ClusterIngress: ci}
+		if err := store.Fetch(d); err != nil {
+			t.Fatalf("Fetch(deployment for %s): %v", ci.Name, err)
+		}
+		return d
+	}
+	fetchService := func(ci *ingressv1alpha1.ClusterIngress) *assetsimple.Service {
+		store := asset.NewStore()
+		s := &assetsimple.Service{Config: cfg, ClusterIngress: ci}
+		if err := store.Fetch(s); err != nil {
+			t.Fatalf("Fetch(service for %s): %v", ci.Name, err)
+		}
+		return s
+	}
+
+	dA, dB := fetchDeployment(a), fetchDeployment(b)
+	if dA.Object.Name == dB.Object.Name {
+		t.Errorf("expected distinct deployment names for distinct ClusterIngresses, both got %q", dA.Object.Name)
+	}
+
+	sA, sB := fetchService(a), fetchService(b)
+	if sA.Object.Name == sB.Object.Name {
+		t.Errorf("expected distinct service names for distinct ClusterIngresses, both got %q", sA.Object.Name)
+	}
+
+	// EnsureDeleted builds the object to delete straight from Config, the
+	// same way: confirm it too resolves to ClusterIngress-specific objects
+	// rather than one shared name that would delete every ClusterIngress's
+	// resources at once.
+	delA, err := cfg.Deployment(a)
+	if err != nil {
+		t.Fatalf("Config.Deployment(a): %v", err)
+	}
+	delB, err := cfg.Deployment(b)
+	if err != nil {
+		t.Fatalf("Config.Deployment(b): %v", err)
+	}
+	if delA.Name == delB.Name {
+		t.Errorf("expected EnsureDeleted to target distinct deployment names per ClusterIngress, both got %q", delA.Name)
+	}
+}
+
+func TestCopyDeploymentIdentityPreservesServerAssignedFields(t *testing.T) {
+	wanted := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx-ingress"}}
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:            "nginx-ingress",
+		UID:             types.UID("abc-123"),
+		ResourceVersion: "42",
+	}}
+
+	copyDeploymentIdentity(wanted, existing)
+
+	if wanted.UID != existing.UID {
+		t.Errorf("UID = %q, want %q", wanted.UID, existing.UID)
+	}
+	if wanted.ResourceVersion != existing.ResourceVersion {
+		t.Errorf("ResourceVersion = %q, want %q", wanted.ResourceVersion, existing.ResourceVersion)
+	}
+}
@@ -0,0 +1,32 @@
+package manifests
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed bindata/assets
+var assets embed.FS
+
+const assetsRoot = "bindata/assets"
+
+// Asset returns the contents of the named embedded manifest asset. It's
+// kept as a thin shim over the embed.FS so call sites don't need to change
+// now that the manifests are compiled in via go:embed instead of a
+// generated go-bindata blob.
+func Asset(name string) ([]byte, error) {
+	bs, err := assets.ReadFile(assetsRoot + "/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("asset %s not found: %v", name, err)
+	}
+	return bs, nil
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+func MustAsset(name string) []byte {
+	bs, err := Asset(name)
+	if err != nil {
+		panic(fmt.Sprintf("asset: MustAsset %s: %v", name, err))
+	}
+	return bs
+}
@@ -0,0 +1,301 @@
+// Package manifests knows how to render the Kubernetes objects the operator
+// needs to stand up a router, from assets embedded via go:embed (see
+// assets.go).
+package manifests
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	yaml "sigs.k8s.io/yaml"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/util"
+)
+
+const (
+	RouterNamespaceAsset          = "router/namespace.yaml"
+	RouterServiceAccountAsset     = "router/service-account.yaml"
+	RouterClusterRoleAsset        = "router/cluster-role.yaml"
+	RouterClusterRoleBindingAsset = "router/cluster-role-binding.yaml"
+	RouterDaemonSetAsset          = "router/daemonset.yaml"
+	RouterServiceCloudAsset       = "router/service-cloud.yaml"
+	RouterServiceNodePortAsset    = "router/service-nodeport.yaml"
+	DefaultClusterIngressAsset    = "cluster-ingress-default.yaml"
+
+	NginxIngressNamespaceAsset      = "nginx-ingress/namespace.yaml"
+	NginxIngressServiceAccountAsset = "nginx-ingress/service-account.yaml"
+	NginxIngressDeploymentAsset     = "nginx-ingress/deployment.yaml"
+	NginxIngressServiceAsset        = "nginx-ingress/service-cloud.yaml"
+
+	ApisixNamespaceAsset      = "apisix/namespace.yaml"
+	ApisixServiceAccountAsset = "apisix/service-account.yaml"
+	ApisixDeploymentAsset     = "apisix/deployment.yaml"
+	ApisixServiceAsset        = "apisix/service-cloud.yaml"
+
+	// hostNetworkNodeSelector marks the nodes a host-network router
+	// DaemonSet should run on, so that it doesn't land on every node in the
+	// cluster and bind to ports 80/443 on nodes that aren't meant to serve
+	// ingress traffic.
+	hostNetworkNodeSelector = "router.openshift.io/hostnetwork"
+)
+
+// Factory knows how to build the resources the operator manages, starting
+// from the static assets compiled into the binary.
+type Factory struct{}
+
+// NewFactory returns a new manifest Factory.
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// DefaultClusterIngress returns the default ClusterIngress the operator
+// creates on startup if one doesn't already exist, customized with details
+// of the cluster it's running against.
+func (f *Factory) DefaultClusterIngress(ic *util.InstallConfig) (*ingressv1alpha1.ClusterIngress, error) {
+	ci := &ingressv1alpha1.ClusterIngress{}
+	if err := decodeAsset(DefaultClusterIngressAsset, ci); err != nil {
+		return nil, err
+	}
+	if ic != nil && len(ic.IngressDomain) > 0 {
+		domain := ic.IngressDomain
+		ci.Spec.IngressDomain = &domain
+	}
+	return ci, nil
+}
+
+// RouterNamespace returns the namespace in which router resources live.
+func (f *Factory) RouterNamespace() (*corev1.Namespace, error) {
+	ns := &corev1.Namespace{}
+	if err := decodeAsset(RouterNamespaceAsset, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// RouterServiceAccount returns the ServiceAccount routers run as.
+func (f *Factory) RouterServiceAccount() (*corev1.ServiceAccount, error) {
+	sa := &corev1.ServiceAccount{}
+	if err := decodeAsset(RouterServiceAccountAsset, sa); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// RouterClusterRole returns the ClusterRole routers need.
+func (f *Factory) RouterClusterRole() (*rbacv1.ClusterRole, error) {
+	cr := &rbacv1.ClusterRole{}
+	if err := decodeAsset(RouterClusterRoleAsset, cr); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// RouterClusterRoleBinding returns the ClusterRoleBinding granting the
+// router's ServiceAccount the RouterClusterRole.
+func (f *Factory) RouterClusterRoleBinding() (*rbacv1.ClusterRoleBinding, error) {
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := decodeAsset(RouterClusterRoleBindingAsset, crb); err != nil {
+		return nil, err
+	}
+	return crb, nil
+}
+
+// RouterDaemonSet returns the DaemonSet for the router backing the given
+// ClusterIngress.
+func (f *Factory) RouterDaemonSet(ci *ingressv1alpha1.ClusterIngress) (*appsv1.DaemonSet, error) {
+	ds := &appsv1.DaemonSet{}
+	if err := decodeAsset(RouterDaemonSetAsset, ds); err != nil {
+		return nil, err
+	}
+	name := routerName(ci)
+	ds.Name = name
+	ds.Labels["router"] = name
+	ds.Spec.Selector.MatchLabels["router"] = name
+	ds.Spec.Template.Labels["router"] = name
+	return ds, nil
+}
+
+// RouterDaemonSetHostNetwork returns the DaemonSet for a router configured
+// for HostNetworkClusterIngressHA: it binds directly to host ports 80/443 on
+// whichever nodes it lands on, so it is restricted via a node selector to
+// nodes meant to serve ingress traffic, and keeps resolving names through
+// cluster DNS (DNSClusterFirstWithHostNet) despite running on the host
+// network.
+func (f *Factory) RouterDaemonSetHostNetwork(ci *ingressv1alpha1.ClusterIngress) (*appsv1.DaemonSet, error) {
+	ds, err := f.RouterDaemonSet(ci)
+	if err != nil {
+		return nil, err
+	}
+	ds.Spec.Template.Spec.HostNetwork = true
+	ds.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	ds.Spec.Template.Spec.NodeSelector = map[string]string{hostNetworkNodeSelector: "true"}
+	return ds, nil
+}
+
+// RouterServiceCloud returns the cloud load balancer Service fronting the
+// router for a ClusterIngress configured for CloudClusterIngressHA.
+func (f *Factory) RouterServiceCloud(ci *ingressv1alpha1.ClusterIngress) (*corev1.Service, error) {
+	s := &corev1.Service{}
+	if err := decodeAsset(RouterServiceCloudAsset, s); err != nil {
+		return nil, err
+	}
+	name := routerName(ci)
+	s.Name = name
+	s.Labels["router"] = name
+	s.Spec.Selector["router"] = name
+	return s, nil
+}
+
+// RouterServiceNodePort returns the NodePort Service fronting the router for
+// a ClusterIngress configured for NodePortClusterIngressHA, for topologies
+// (bare-metal, on-prem) that front the cluster with an external load
+// balancer of their own rather than a cloud-provisioned one.
+func (f *Factory) RouterServiceNodePort(ci *ingressv1alpha1.ClusterIngress) (*corev1.Service, error) {
+	s := &corev1.Service{}
+	if err := decodeAsset(RouterServiceNodePortAsset, s); err != nil {
+		return nil, err
+	}
+	name := routerName(ci)
+	s.Name = name
+	s.Labels["router"] = name
+	s.Spec.Selector["router"] = name
+	return s, nil
+}
+
+// routerName returns the name to use for per-ClusterIngress router
+// resources.
+func routerName(ci *ingressv1alpha1.ClusterIngress) string {
+	return fmt.Sprintf("router-%s", ci.Name)
+}
+
+// NginxIngressNamespace returns the namespace the nginx-ingress backend's
+// resources live in.
+func (f *Factory) NginxIngressNamespace() (*corev1.Namespace, error) {
+	ns := &corev1.Namespace{}
+	if err := decodeAsset(NginxIngressNamespaceAsset, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// NginxIngressServiceAccount returns the ServiceAccount the nginx-ingress
+// backend runs as.
+func (f *Factory) NginxIngressServiceAccount() (*corev1.ServiceAccount, error) {
+	sa := &corev1.ServiceAccount{}
+	if err := decodeAsset(NginxIngressServiceAccountAsset, sa); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// NginxIngressDeployment returns the Deployment for the nginx-ingress
+// backend backing the given ClusterIngress, sized to its requested replica
+// count.
+func (f *Factory) NginxIngressDeployment(ci *ingressv1alpha1.ClusterIngress) (*appsv1.Deployment, error) {
+	d := &appsv1.Deployment{}
+	if err := decodeAsset(NginxIngressDeploymentAsset, d); err != nil {
+		return nil, err
+	}
+	name := nginxIngressName(ci)
+	d.Name = name
+	d.Labels["nginx-ingress"] = name
+	d.Spec.Selector.MatchLabels["nginx-ingress"] = name
+	d.Spec.Template.Labels["nginx-ingress"] = name
+	if ci.Spec.Replicas > 0 {
+		d.Spec.Replicas = &ci.Spec.Replicas
+	}
+	return d, nil
+}
+
+// NginxIngressService returns the cloud load balancer Service fronting the
+// nginx-ingress backend for the given ClusterIngress.
+func (f *Factory) NginxIngressService(ci *ingressv1alpha1.ClusterIngress) (*corev1.Service, error) {
+	s := &corev1.Service{}
+	if err := decodeAsset(NginxIngressServiceAsset, s); err != nil {
+		return nil, err
+	}
+	name := nginxIngressName(ci)
+	s.Name = name
+	s.Labels["nginx-ingress"] = name
+	s.Spec.Selector["nginx-ingress"] = name
+	return s, nil
+}
+
+// nginxIngressName returns the name to use for per-ClusterIngress
+// nginx-ingress resources.
+func nginxIngressName(ci *ingressv1alpha1.ClusterIngress) string {
+	return fmt.Sprintf("nginx-ingress-%s", ci.Name)
+}
+
+// ApisixNamespace returns the namespace the apisix backend's resources
+// live in.
+func (f *Factory) ApisixNamespace() (*corev1.Namespace, error) {
+	ns := &corev1.Namespace{}
+	if err := decodeAsset(ApisixNamespaceAsset, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// ApisixServiceAccount returns the ServiceAccount the apisix backend runs
+// as.
+func (f *Factory) ApisixServiceAccount() (*corev1.ServiceAccount, error) {
+	sa := &corev1.ServiceAccount{}
+	if err := decodeAsset(ApisixServiceAccountAsset, sa); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// ApisixDeployment returns the Deployment for the apisix backend backing
+// the given ClusterIngress, sized to its requested replica count.
+func (f *Factory) ApisixDeployment(ci *ingressv1alpha1.ClusterIngress) (*appsv1.Deployment, error) {
+	d := &appsv1.Deployment{}
+	if err := decodeAsset(ApisixDeploymentAsset, d); err != nil {
+		return nil, err
+	}
+	name := apisixName(ci)
+	d.Name = name
+	d.Labels["apisix"] = name
+	d.Spec.Selector.MatchLabels["apisix"] = name
+	d.Spec.Template.Labels["apisix"] = name
+	if ci.Spec.Replicas > 0 {
+		d.Spec.Replicas = &ci.Spec.Replicas
+	}
+	return d, nil
+}
+
+// ApisixService returns the cloud load balancer Service fronting the
+// apisix backend for the given ClusterIngress.
+func (f *Factory) ApisixService(ci *ingressv1alpha1.ClusterIngress) (*corev1.Service, error) {
+	s := &corev1.Service{}
+	if err := decodeAsset(ApisixServiceAsset, s); err != nil {
+		return nil, err
+	}
+	name := apisixName(ci)
+	s.Name = name
+	s.Labels["apisix"] = name
+	s.Spec.Selector["apisix"] = name
+	return s, nil
+}
+
+// apisixName returns the name to use for per-ClusterIngress apisix
+// resources.
+func apisixName(ci *ingressv1alpha1.ClusterIngress) string {
+	return fmt.Sprintf("apisix-%s", ci.Name)
+}
+
+func decodeAsset(name string, obj interface{}) error {
+	bs, err := Asset(name)
+	if err != nil {
+		return fmt.Errorf("couldn't read asset %q: %v", name, err)
+	}
+	if err := yaml.Unmarshal(bs, obj); err != nil {
+		return fmt.Errorf("couldn't decode asset %q: %v", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package manifests
+
+import (
+	"testing"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+)
+
+// Regression test for a bug where NginxIngressDeployment/Service and
+// ApisixDeployment/Service returned the static asset name unmodified,
+// so every ClusterIngress selecting the same backend rendered the same
+// Deployment/Service and fought over it on every reconcile.
+func TestNginxIngressAndApisixResourcesAreNamedPerClusterIngress(t *testing.T) {
+	f := NewFactory()
+	a := &ingressv1alpha1.ClusterIngress{}
+	a.Name = "a"
+	b := &ingressv1alpha1.ClusterIngress{}
+	b.Name = "b"
+
+	nginxDeploymentA, err := f.NginxIngressDeployment(a)
+	if err != nil {
+		t.Fatalf("NginxIngressDeployment(a): %v", err)
+	}
+	nginxDeploymentB, err := f.NginxIngressDeployment(b)
+	if err != nil {
+		t.Fatalf("NginxIngressDeployment(b): %v", err)
+	}
+	if nginxDeploymentA.Name == nginxDeploymentB.Name {
+		t.Errorf("expected distinct nginx-ingress deployment names per ClusterIngress, both got %q", nginxDeploymentA.Name)
+	}
+
+	nginxServiceA, err := f.NginxIngressService(a)
+	if err != nil {
+		t.Fatalf("NginxIngressService(a): %v", err)
+	}
+	nginxServiceB, err := f.NginxIngressService(b)
+	if err != nil {
+		t.Fatalf("NginxIngressService(b): %v", err)
+	}
+	if nginxServiceA.Name == nginxServiceB.Name {
+		t.Errorf("expected distinct nginx-ingress service names per ClusterIngress, both got %q", nginxServiceA.Name)
+	}
+
+	apisixDeploymentA, err := f.ApisixDeployment(a)
+	if err != nil {
+		t.Fatalf("ApisixDeployment(a): %v", err)
+	}
+	apisixDeploymentB, err := f.ApisixDeployment(b)
+	if err != nil {
+		t.Fatalf("ApisixDeployment(b): %v", err)
+	}
+	if apisixDeploymentA.Name == apisixDeploymentB.Name {
+		t.Errorf("expected distinct apisix deployment names per ClusterIngress, both got %q", apisixDeploymentA.Name)
+	}
+
+	apisixServiceA, err := f.ApisixService(a)
+	if err != nil {
+		t.Fatalf("ApisixService(a): %v", err)
+	}
+	apisixServiceB, err := f.ApisixService(b)
+	if err != nil {
+		t.Fatalf("ApisixService(b): %v", err)
+	}
+	if apisixServiceA.Name == apisixServiceB.Name {
+		t.Errorf("expected distinct apisix service names per ClusterIngress, both got %q", apisixServiceA.Name)
+	}
+}
@@ -3,10 +3,12 @@ package stub
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
 	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/backend"
 	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
 	"github.com/openshift/cluster-ingress-operator/pkg/util"
 	"github.com/openshift/cluster-ingress-operator/pkg/util/slice"
@@ -16,6 +18,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/workqueue"
+
+	// Side-effect import every built-in backend so it registers itself with
+	// package backend on startup.
+	_ "github.com/openshift/cluster-ingress-operator/pkg/backend/apisix"
+	_ "github.com/openshift/cluster-ingress-operator/pkg/backend/nginxingress"
+	_ "github.com/openshift/cluster-ingress-operator/pkg/backend/openshiftrouter"
 )
 
 const (
@@ -30,22 +39,165 @@ const (
 	// to handle all states.
 	// TODO: Make this generic and not tied to the "default" ingress.
 	ClusterIngressFinalizer = "ingress.openshift.io/default-cluster-ingress"
+
+	// workers is the number of goroutines processing the reconcile queue.
+	workers = 2
+
+	// maxRetries is the number of times a reconcile will be retried before
+	// it is dropped from the queue, per the rate limiter's backoff.
+	maxRetries = 15
+
+	// backendAnnotation records the name of the IngressBackend last
+	// successfully reconciled for a ClusterIngress, so that switching
+	// IngressController to a different backend - or deleting a
+	// ClusterIngress after such a switch - tears down the previous
+	// backend's resources instead of leaking them.
+	backendAnnotation = "ingress.openshift.io/backend"
 )
 
 type Handler struct {
 	InstallConfig   *util.InstallConfig
 	ManifestFactory *manifests.Factory
 	Namespace       string
+
+	queueOnce sync.Once
+	queue     workqueue.RateLimitingInterface
+
+	// reconcileFunc is used in place of h.reconcile when set, so that tests
+	// can exercise the queue's retry/backoff behavior without a live API
+	// server.
+	reconcileFunc func(name string) error
+
+	// rateLimiter is used in place of workqueue.DefaultControllerRateLimiter
+	// when set, so tests can exercise retry/backoff behavior without waiting
+	// out its realistic (multi-minute, for many retries) exponential delays.
+	rateLimiter workqueue.RateLimiter
+
+	// backendsMu guards backends.
+	backendsMu sync.Mutex
+
+	// backends caches the IngressBackend constructed for each backend name,
+	// since a backend holds state (its apply-if-changed hash cache) that
+	// must persist across reconciles rather than being rebuilt each time.
+	backends map[string]backend.IngressBackend
+}
+
+// backendFor returns the IngressBackend that provisions ci, constructing
+// and caching it on first use.
+func (h *Handler) backendFor(ci *ingressv1alpha1.ClusterIngress) (backend.IngressBackend, error) {
+	name := ci.IngressControllerName()
+
+	h.backendsMu.Lock()
+	defer h.backendsMu.Unlock()
+	if h.backends == nil {
+		h.backends = map[string]backend.IngressBackend{}
+	}
+	if b, ok := h.backends[name]; ok {
+		return b, nil
+	}
+
+	b, ok := backend.New(name, h.ManifestFactory)
+	if !ok {
+		return nil, fmt.Errorf("no ingress backend registered under name %q", name)
+	}
+	h.backends[name] = b
+	return b, nil
+}
+
+// ensurePreviousBackendCleanedUp tears down the IngressBackend ci was last
+// successfully reconciled against, if its IngressController has since
+// changed to name a different one, so that switching backends - or
+// deleting a ClusterIngress after such a switch - doesn't orphan the
+// resources the old backend provisioned for ci. What is removed is what
+// that backend's EnsureDeleted removes: the DaemonSet or Deployment and the
+// Service, plus the namespace and ClusterRoleBinding of an openshift-router
+// shard. Namespaces and RBAC shared across ClusterIngresses stay in place.
+func (h *Handler) ensurePreviousBackendCleanedUp(ci *ingressv1alpha1.ClusterIngress) error {
+	previousName, ok := ci.Annotations[backendAnnotation]
+	if !ok || previousName == ci.IngressControllerName() {
+		return nil
+	}
+
+	previous, ok := backend.New(previousName, h.ManifestFactory)
+	if !ok {
+		// Whatever used to be registered under this name is gone from the
+		// binary; nothing left to clean up after.
+		return nil
+	}
+	return previous.EnsureDeleted(ci)
 }
 
+// Handle enqueues a rate limited reconcile for the ClusterIngress named by
+// the event rather than reconciling inline, so that a burst of updates
+// coalesces into a single reconcile and a failing reconcile is retried with
+// exponential backoff instead of being silently dropped.
 func (h *Handler) Handle(ctx context.Context, event sdk.Event) error {
-	// TODO: This should be adding an item to a rate limited work queue, but for
-	// now correctness is more important than performance.
+	h.queueOnce.Do(h.startWorkers)
+
 	switch o := event.Object.(type) {
 	case *ingressv1alpha1.ClusterIngress:
-		logrus.Infof("reconciling for update to clusteringress %q", o.Name)
+		logrus.Infof("queuing reconcile for update to clusteringress %q", o.Name)
+		h.queue.Add(o.Name)
+	}
+	return nil
+}
+
+// startWorkers initializes the work queue and launches the worker
+// goroutines that drain it. It is called exactly once, on the first Handle.
+func (h *Handler) startWorkers() {
+	limiter := h.rateLimiter
+	if limiter == nil {
+		limiter = workqueue.DefaultControllerRateLimiter()
+	}
+	h.queue = workqueue.NewRateLimitingQueue(limiter)
+	for i := 0; i < workers; i++ {
+		go h.worker()
+	}
+}
+
+// worker pulls keys off the queue until it is shut down.
+func (h *Handler) worker() {
+	for h.processNextItem() {
+	}
+}
+
+// processNextItem pops a single key from the queue and reconciles it,
+// requeueing with backoff on error. It returns false once the queue has
+// been shut down.
+func (h *Handler) processNextItem() bool {
+	key, quit := h.queue.Get()
+	if quit {
+		return false
+	}
+	defer h.queue.Done(key)
+
+	name, ok := key.(string)
+	if !ok {
+		logrus.Errorf("unexpected key type in reconcile queue: %T", key)
+		h.queue.Forget(key)
+		return true
+	}
+
+	reconcile := h.reconcile
+	if h.reconcileFunc != nil {
+		reconcile = h.reconcileFunc
+	}
+
+	err := reconcile(name)
+	if err == nil {
+		h.queue.Forget(key)
+		return true
+	}
+
+	if h.queue.NumRequeues(key) < maxRetries {
+		logrus.Errorf("error reconciling clusteringress %q, retrying: %v", name, err)
+		h.queue.AddRateLimited(key)
+		return true
 	}
-	return h.reconcile()
+
+	logrus.Errorf("error reconciling clusteringress %q, giving up after %d retries: %v", name, maxRetries, err)
+	h.queue.Forget(key)
+	return true
 }
 
 // EnsureDefaultClusterIngress ensures that a default ClusterIngress exists.
@@ -64,169 +216,145 @@ func (h *Handler) EnsureDefaultClusterIngress() error {
 	return nil
 }
 
-// Reconcile performs a full reconciliation loop for ingress, including
-// generalized setup and handling of all clusteringress resources in the
-// operator namespace.
-func (h *Handler) reconcile() error {
-	// Ensure we have all the necessary scaffolding on which to place router
-	// instances.
-	err := h.ensureRouterNamespace()
-	if err != nil {
-		return err
-	}
-
-	// Find all clusteringresses.
-	ingresses := &ingressv1alpha1.ClusterIngressList{
+// reconcile performs a full reconciliation pass for the named ClusterIngress.
+func (h *Handler) reconcile(name string) error {
+	ci := &ingressv1alpha1.ClusterIngress{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ClusterIngress",
 			APIVersion: "ingress.openshift.io/v1alpha1",
 		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: h.Namespace,
+		},
 	}
-	err = sdk.List(h.Namespace, ingresses, sdk.WithListOptions(&metav1.ListOptions{}))
+	if err := sdk.Get(ci); err != nil {
+		if errors.IsNotFound(err) {
+			// The ClusterIngress was deleted before we got to it; nothing
+			// left to reconcile.
+			return nil
+		}
+		return fmt.Errorf("failed to get clusteringress %q: %v", name, err)
+	}
+
+	b, err := h.backendFor(ci)
 	if err != nil {
-		return fmt.Errorf("failed to list clusteringresses: %v", err)
+		return fmt.Errorf("couldn't resolve ingress backend for clusteringress %q: %v", ci.Name, err)
 	}
 
-	// Reconcile all the ingresses.
-	errors := []error{}
-	for _, ingress := range ingresses.Items {
+	if err := h.ensurePreviousBackendCleanedUp(ci); err != nil {
+		return fmt.Errorf("couldn't clean up previous ingress backend for clusteringress %q: %v", ci.Name, err)
+	}
+
+	if ci.DeletionTimestamp != nil {
 		// Handle deleted ingress.
 		// TODO: Assert/ensure that the ingress has a finalizer so we can reliably detect
 		// deletion.
-		if ingress.DeletionTimestamp != nil {
-			// Destroy any router associated with the clusteringress.
-			err := h.ensureRouterDeleted(&ingress)
-			if err != nil {
-				errors = append(errors, fmt.Errorf("couldn't delete clusteringress %q: %v", ingress.Name, err))
-				continue
-			}
-			// Clean up the finalizer to allow the clusteringress to be deleted.
-			if slice.ContainsString(ingress.Finalizers, ClusterIngressFinalizer) {
-				ingress.Finalizers = slice.RemoveString(ingress.Finalizers, ClusterIngressFinalizer)
-				err = sdk.Update(&ingress)
-				if err != nil {
-					errors = append(errors, fmt.Errorf("couldn't remove finalizer from clusteringress %q: %v", ingress.Name, err))
-				}
+		if err := b.EnsureDeleted(ci); err != nil {
+			return fmt.Errorf("couldn't delete clusteringress %q: %v", ci.Name, err)
+		}
+		// Clean up the finalizer to allow the clusteringress to be deleted.
+		if slice.ContainsString(ci.Finalizers, ClusterIngressFinalizer) {
+			ci.Finalizers = slice.RemoveString(ci.Finalizers, ClusterIngressFinalizer)
+			if err := sdk.Update(ci); err != nil {
+				return fmt.Errorf("couldn't remove finalizer from clusteringress %q: %v", ci.Name, err)
 			}
-			continue
 		}
+		return nil
+	}
 
-		// Handle active ingress.
-		err := h.ensureRouterForIngress(&ingress)
-		if err != nil {
-			errors = append(errors, fmt.Errorf("couldn't ensure clusteringress %q: %v", ingress.Name, err))
+	// Handle active ingress, recording the outcome as a status condition so
+	// that failures are visible on the resource instead of only in the
+	// operator's logs.
+	reconcileErr := h.ensureClusterIngress(ci, b)
+	if reconcileErr == nil {
+		if ci.Annotations == nil {
+			ci.Annotations = map[string]string{}
 		}
+		ci.Annotations[backendAnnotation] = b.Name()
 	}
-	return utilerrors.NewAggregate(errors)
-}
-
-// ensureRouterNamespace ensures all the necessary scaffolding exists for
-// routers generally, including a namespace and all RBAC setup.
-func (h *Handler) ensureRouterNamespace() error {
-	cr, err := h.ManifestFactory.RouterClusterRole()
-	if err != nil {
-		return fmt.Errorf("couldn't build router cluster role: %v", err)
+	h.setReconcileStatus(ci, reconcileErr)
+	if updateErr := sdk.Update(ci); updateErr != nil {
+		errs := []error{updateErr}
+		if reconcileErr != nil {
+			errs = append(errs, reconcileErr)
+		}
+		return utilerrors.NewAggregate(errs)
 	}
-	err = sdk.Create(cr)
-	if err == nil {
-		logrus.Infof("created router cluster role %q", cr.Name)
-	} else if !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("couldn't create router cluster role: %v", err)
+	if reconcileErr != nil {
+		return fmt.Errorf("couldn't ensure clusteringress %q: %v", ci.Name, reconcileErr)
 	}
+	return nil
+}
 
-	ns, err := h.ManifestFactory.RouterNamespace()
-	if err != nil {
-		return fmt.Errorf("couldn't build router namespace: %v", err)
-	}
-	err = sdk.Create(ns)
-	if err == nil {
-		logrus.Infof("created router namespace %q", ns.Name)
-	} else if !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("couldn't create router namespace %q: %v", ns.Name, err)
+// ensureClusterIngress provisions everything a single ClusterIngress needs:
+// validation for a shard, then handing off to its ingress backend to
+// provision the data plane itself.
+func (h *Handler) ensureClusterIngress(ci *ingressv1alpha1.ClusterIngress, b backend.IngressBackend) error {
+	if err := h.validateShard(ci); err != nil {
+		return err
 	}
+	return b.EnsureDeployed(ci)
+}
 
-	sa, err := h.ManifestFactory.RouterServiceAccount()
-	if err != nil {
-		return fmt.Errorf("couldn't build router service account: %v", err)
+// validateShard checks a shard ClusterIngress's RouteSelector and
+// NamespaceSelector against every other shard's, so two shards can't both
+// claim overlapping routes. It is a no-op for the non-sharded default
+// ClusterIngress.
+func (h *Handler) validateShard(ci *ingressv1alpha1.ClusterIngress) error {
+	if !ci.IsShard() {
+		return nil
 	}
-	err = sdk.Create(sa)
-	if err == nil {
-		logrus.Infof("created router service account %s/%s", sa.Namespace, sa.Name)
-	} else if !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("couldn't create router service account %s/%s: %v", sa.Namespace, sa.Name, err)
-	}
-
-	crb, err := h.ManifestFactory.RouterClusterRoleBinding()
-	if err != nil {
-		return fmt.Errorf("couldn't build router cluster role binding: %v", err)
+	list := &ingressv1alpha1.ClusterIngressList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterIngress",
+			APIVersion: "ingress.openshift.io/v1alpha1",
+		},
 	}
-	err = sdk.Create(crb)
-	if err == nil {
-		logrus.Infof("created router cluster role binding %q", crb.Name)
-	} else if !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("couldn't create router cluster role binding: %v", err)
+	if err := sdk.List(h.Namespace, list); err != nil {
+		return fmt.Errorf("couldn't list clusteringresses to validate shard %q: %v", ci.Name, err)
 	}
-
-	return nil
+	return ingressv1alpha1.ValidateShard(ci, list.Items)
 }
 
-// ensureRouterForIngress ensures all necessary router resources exist for a
-// given clusteringress.
-func (h *Handler) ensureRouterForIngress(ci *ingressv1alpha1.ClusterIngress) error {
-	ds, err := h.ManifestFactory.RouterDaemonSet(ci)
-	if err != nil {
-		return fmt.Errorf("couldn't build daemonset: %v", err)
-	}
-	err = sdk.Create(ds)
-	if errors.IsAlreadyExists(err) {
-		if err = sdk.Get(ds); err != nil {
-			return fmt.Errorf("couldn't get daemonset %s, %v", ds.Name, err)
-		}
-	} else if err != nil {
-		return fmt.Errorf("failed to create daemonset %s/%s: %v", ds.Namespace, ds.Name, err)
-	} else {
-		logrus.Infof("created router daemonset %s/%s", ds.Namespace, ds.Name)
-	}
-
-	if ci.Spec.HighAvailability != nil {
-		switch ci.Spec.HighAvailability.Type {
-		case ingressv1alpha1.CloudClusterIngressHA:
-			service, err := h.ManifestFactory.RouterServiceCloud(ci)
-			if err != nil {
-				return fmt.Errorf("couldn't build service: %v", err)
-			}
-			trueVar := true
-			dsRef := metav1.OwnerReference{
-				APIVersion: ds.APIVersion,
-				Kind:       ds.Kind,
-				Name:       ds.Name,
-				UID:        ds.UID,
-				Controller: &trueVar,
-			}
-			service.SetOwnerReferences([]metav1.OwnerReference{dsRef})
-
-			err = sdk.Create(service)
-			if err == nil {
-				logrus.Infof("created router service %s/%s", service.Namespace, service.Name)
-			} else if !errors.IsAlreadyExists(err) {
-				return fmt.Errorf("failed to create service %s/%s: %v", service.Namespace, service.Name, err)
-			}
-		}
+// setReconcileStatus records the outcome of a reconcile attempt as
+// Available/Progressing/Degraded conditions on the ClusterIngress.
+func (h *Handler) setReconcileStatus(ci *ingressv1alpha1.ClusterIngress, reconcileErr error) {
+	now := metav1.Now()
+	if reconcileErr != nil {
+		ingressv1alpha1.SetClusterIngressCondition(&ci.Status, ingressv1alpha1.ClusterIngressCondition{
+			Type:               ingressv1alpha1.ClusterIngressDegraded,
+			Status:             ingressv1alpha1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             "ReconcileError",
+			Message:            reconcileErr.Error(),
+		})
+		ingressv1alpha1.SetClusterIngressCondition(&ci.Status, ingressv1alpha1.ClusterIngressCondition{
+			Type:               ingressv1alpha1.ClusterIngressAvailable,
+			Status:             ingressv1alpha1.ConditionFalse,
+			LastTransitionTime: now,
+			Reason:             "ReconcileError",
+			Message:            reconcileErr.Error(),
+		})
+		return
 	}
 
-	return nil
-}
-
-// ensureRouterDeleted ensures that any router resources associated with the
-// clusteringress are deleted.
-func (h *Handler) ensureRouterDeleted(ci *ingressv1alpha1.ClusterIngress) error {
-	ds, err := h.ManifestFactory.RouterDaemonSet(ci)
-	if err != nil {
-		return fmt.Errorf("couldn't build DaemonSet object for deletion: %v", err)
-	}
-	err = sdk.Delete(ds)
-	if !errors.IsNotFound(err) {
-		return err
-	}
-	return nil
+	ingressv1alpha1.SetClusterIngressCondition(&ci.Status, ingressv1alpha1.ClusterIngressCondition{
+		Type:               ingressv1alpha1.ClusterIngressDegraded,
+		Status:             ingressv1alpha1.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             "ReconcileSucceeded",
+	})
+	ingressv1alpha1.SetClusterIngressCondition(&ci.Status, ingressv1alpha1.ClusterIngressCondition{
+		Type:               ingressv1alpha1.ClusterIngressAvailable,
+		Status:             ingressv1alpha1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             "ReconcileSucceeded",
+	})
+	ingressv1alpha1.SetClusterIngressCondition(&ci.Status, ingressv1alpha1.ClusterIngressCondition{
+		Type:               ingressv1alpha1.ClusterIngressProgressing,
+		Status:             ingressv1alpha1.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             "ReconcileSucceeded",
+	})
 }
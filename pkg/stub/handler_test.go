@@ -0,0 +1,189 @@
+package stub
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	ingressv1alpha1 "github.com/openshift/cluster-ingress-operator/pkg/apis/ingress/v1alpha1"
+	"github.com/openshift/cluster-ingress-operator/pkg/backend"
+	"github.com/openshift/cluster-ingress-operator/pkg/manifests"
+)
+
+// newTestHandler returns a Handler with its queue already started, wired to
+// reconcileFunc instead of the real reconcile so tests don't need a live API
+// server. The real DefaultControllerRateLimiter's exponential backoff would
+// make a test retrying maxRetries times take minutes, so tests get a
+// near-instant one instead; the retry/backoff behavior under test is the
+// queue's requeue decision, not the limiter's specific delay curve.
+func newTestHandler(reconcileFunc func(name string) error) *Handler {
+	h := &Handler{
+		reconcileFunc: reconcileFunc,
+		rateLimiter:   workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, 10*time.Millisecond),
+	}
+	h.queueOnce.Do(h.startWorkers)
+	return h
+}
+
+func TestProcessNextItemRetriesOnError(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	failUntil := 3
+	succeeded := make(chan struct{})
+
+	h := newTestHandler(func(name string) error {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n < failUntil {
+			return fmt.Errorf("transient error")
+		}
+		close(succeeded)
+		return nil
+	})
+
+	h.queue.Add("default")
+
+	select {
+	case <-succeeded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reconcile did not succeed within 5s")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != failUntil {
+		t.Errorf("expected %d attempts before success, got %d", failUntil, attempts)
+	}
+}
+
+func TestProcessNextItemGivesUpAfterMaxRetries(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	// Counting down with a WaitGroup, rather than polling queue.Len(),
+	// gives a deterministic signal for "the initial attempt plus maxRetries
+	// retries have happened" - queue.Len() doesn't reflect
+	// requeued-but-not-yet-due items sitting in the rate limiter's delay
+	// timer, so polling it races with, and can wildly undercount, in-flight
+	// retries.
+	var wg sync.WaitGroup
+	wg.Add(maxRetries + 1)
+
+	h := newTestHandler(func(name string) error {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		wg.Done()
+		return fmt.Errorf("permanent error")
+	})
+
+	h.queue.Add("default")
+
+	allAttempted := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(allAttempted)
+	}()
+
+	select {
+	case <-allAttempted:
+	case <-time.After(10 * time.Second):
+		mu.Lock()
+		t.Fatalf("expected %d attempts within 10s, only saw %d", maxRetries+1, attempts)
+		mu.Unlock()
+	}
+
+	// Give processNextItem a moment to Forget the key after the final
+	// retry, so an off-by-one that requeues once more shows up as an extra
+	// attempt below instead of racing the assertion.
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != maxRetries+1 {
+		t.Errorf("expected exactly %d attempts (1 initial + %d retries), got %d", maxRetries+1, maxRetries, attempts)
+	}
+}
+
+func TestSetReconcileStatusTransitionsConditions(t *testing.T) {
+	h := &Handler{}
+	ci := &ingressv1alpha1.ClusterIngress{}
+
+	h.setReconcileStatus(ci, fmt.Errorf("boom"))
+	if ingressv1alpha1.IsClusterIngressConditionTrue(&ci.Status, ingressv1alpha1.ClusterIngressAvailable) {
+		t.Errorf("expected Available=False after a failed reconcile")
+	}
+	if !ingressv1alpha1.IsClusterIngressConditionTrue(&ci.Status, ingressv1alpha1.ClusterIngressDegraded) {
+		t.Errorf("expected Degraded=True after a failed reconcile")
+	}
+
+	h.setReconcileStatus(ci, nil)
+	if !ingressv1alpha1.IsClusterIngressConditionTrue(&ci.Status, ingressv1alpha1.ClusterIngressAvailable) {
+		t.Errorf("expected Available=True after a successful reconcile")
+	}
+	if ingressv1alpha1.IsClusterIngressConditionTrue(&ci.Status, ingressv1alpha1.ClusterIngressDegraded) {
+		t.Errorf("expected Degraded=False after a successful reconcile")
+	}
+}
+
+// fakeDeletableBackend records whether EnsureDeleted was called on it, so
+// tests can assert a stale backend got torn down without a live API server.
+type fakeDeletableBackend struct {
+	name    string
+	deleted bool
+}
+
+func (b *fakeDeletableBackend) Name() string { return b.name }
+func (b *fakeDeletableBackend) EnsureDeployed(*ingressv1alpha1.ClusterIngress) error {
+	return nil
+}
+func (b *fakeDeletableBackend) EnsureDeleted(*ingressv1alpha1.ClusterIngress) error {
+	b.deleted = true
+	return nil
+}
+
+func TestEnsurePreviousBackendCleanedUpDeletesStaleBackend(t *testing.T) {
+	stale := &fakeDeletableBackend{name: "stale-backend"}
+	backend.Register("stale-backend", func(f *manifests.Factory) backend.IngressBackend { return stale })
+	backend.Register("current-backend", func(f *manifests.Factory) backend.IngressBackend {
+		return &fakeDeletableBackend{name: "current-backend"}
+	})
+
+	currentName := "current-backend"
+	ci := &ingressv1alpha1.ClusterIngress{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{backendAnnotation: "stale-backend"}},
+		Spec:       ingressv1alpha1.ClusterIngressSpec{IngressController: &currentName},
+	}
+
+	h := &Handler{}
+	if err := h.ensurePreviousBackendCleanedUp(ci); err != nil {
+		t.Fatalf("ensurePreviousBackendCleanedUp: %v", err)
+	}
+	if !stale.deleted {
+		t.Error("expected the stale backend's resources to be deleted after an IngressController switch")
+	}
+}
+
+func TestEnsurePreviousBackendCleanedUpNoopWhenUnchanged(t *testing.T) {
+	current := &fakeDeletableBackend{name: "current-backend"}
+	backend.Register("current-backend", func(f *manifests.Factory) backend.IngressBackend { return current })
+
+	currentName := "current-backend"
+	ci := &ingressv1alpha1.ClusterIngress{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{backendAnnotation: "current-backend"}},
+		Spec:       ingressv1alpha1.ClusterIngressSpec{IngressController: &currentName},
+	}
+
+	h := &Handler{}
+	if err := h.ensurePreviousBackendCleanedUp(ci); err != nil {
+		t.Fatalf("ensurePreviousBackendCleanedUp: %v", err)
+	}
+	if current.deleted {
+		t.Error("expected no cleanup when the IngressController hasn't changed")
+	}
+}
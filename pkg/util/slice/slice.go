@@ -0,0 +1,25 @@
+// Package slice provides small helpers for working with string slices, used
+// primarily for finalizer bookkeeping.
+package slice
+
+// ContainsString returns true if s contains the given string.
+func ContainsString(s []string, str string) bool {
+	for _, v := range s {
+		if v == str {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveString returns a copy of s with all occurrences of str removed.
+func RemoveString(s []string, str string) []string {
+	result := make([]string, 0, len(s))
+	for _, v := range s {
+		if v == str {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,19 @@
+// Package util contains small helpers shared across the operator that don't
+// have an obvious home of their own.
+package util
+
+// InstallConfig holds the subset of the openshift-installer cluster config
+// that the operator needs in order to provision a default ClusterIngress,
+// read from the "cluster-config-v1" config map in kube-system.
+type InstallConfig struct {
+	// ClusterID is the unique identifier of the cluster.
+	ClusterID string `json:"clusterID"`
+
+	// Platform is the name of the target infrastructure platform, e.g.
+	// "aws", "libvirt", "none".
+	Platform string `json:"platform"`
+
+	// IngressDomain is the base domain under which cluster ingress routes
+	// should be created.
+	IngressDomain string `json:"ingressDomain"`
+}
@@ -0,0 +1,86 @@
+// Package manifests embeds the sample "app-ingress" application used by the
+// operator's end-to-end tests to exercise a real ClusterIngress.
+package manifests
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+//go:embed assets
+var assets embed.FS
+
+const assetsRoot = "assets"
+
+// Asset returns the contents of the named embedded test asset, e.g.
+// "app-ingress/deployment.yaml".
+func Asset(name string) ([]byte, error) {
+	bs, err := assets.ReadFile(filepath.Join(assetsRoot, name))
+	if err != nil {
+		return nil, fmt.Errorf("asset %s not found: %v", name, err)
+	}
+	return bs, nil
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+func MustAsset(name string) []byte {
+	bs, err := Asset(name)
+	if err != nil {
+		panic(fmt.Sprintf("asset: MustAsset %s: %v", name, err))
+	}
+	return bs
+}
+
+// AssetNames returns the names of all embedded test assets.
+func AssetNames() ([]string, error) {
+	var names []string
+	err := fs.WalkDir(assets, assetsRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			rel, err := filepath.Rel(assetsRoot, path)
+			if err != nil {
+				return err
+			}
+			names = append(names, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// RestoreAsset writes the named embedded asset out to dir, preserving its
+// relative path, for tests that need it on disk (e.g. to hand to `oc
+// apply`).
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	dest := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, data, 0644)
+}
+
+// RestoreAssets recursively writes every embedded asset out to dir.
+func RestoreAssets(dir string) error {
+	names, err := AssetNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := RestoreAsset(dir, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}